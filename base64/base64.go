@@ -10,16 +10,30 @@ func Encode(in []byte) (string, error) {
 	return b64.StdEncoding.EncodeToString(in), nil
 }
 
-// Decode - Decode a base64-encoded string
+// EncodeURL - Encode data in the URL-safe base64 format, without padding (as
+// used in JWTs, for example)
+func EncodeURL(in []byte) (string, error) {
+	return b64.RawURLEncoding.EncodeToString(in), nil
+}
+
+// Decode - Decode a base64-encoded string. Both the standard and URL-safe
+// encodings are supported, with or without padding. Newlines are ignored.
 func Decode(in string) ([]byte, error) {
-	o, err := b64.StdEncoding.DecodeString(in)
-	if err != nil {
-		// maybe it's in the URL variant?
-		o, err = b64.URLEncoding.DecodeString(in)
-		if err != nil {
-			// ok, just give up...
-			return nil, err
+	// try the padded encodings first, falling back to the unpadded ones
+	var firstErr error
+	for _, enc := range []*b64.Encoding{
+		b64.StdEncoding, b64.URLEncoding,
+		b64.RawStdEncoding, b64.RawURLEncoding,
+	} {
+		o, err := enc.DecodeString(in)
+		if err == nil {
+			return o, nil
+		}
+		if firstErr == nil {
+			firstErr = err
 		}
 	}
-	return o, nil
+
+	// ok, just give up...
+	return nil, firstErr
 }
@@ -25,6 +25,14 @@ func TestEncode(t *testing.T) {
 	assert.Equal(t, "A+B/", must(Encode([]byte{0x03, 0xe0, 0x7f})))
 }
 
+func TestEncodeURL(t *testing.T) {
+	assert.Equal(t, "", must(EncodeURL([]byte(""))))
+	assert.Equal(t, "Zg", must(EncodeURL([]byte("f"))))
+	assert.Equal(t, "Zm8", must(EncodeURL([]byte("fo"))))
+	assert.Equal(t, "Zm9v", must(EncodeURL([]byte("foo"))))
+	assert.Equal(t, "A-B_", must(EncodeURL([]byte{0x03, 0xe0, 0x7f})))
+}
+
 func TestDecode(t *testing.T) {
 	assert.Equal(t, []byte(""), must(Decode("")))
 	assert.Equal(t, []byte("f"), must(Decode("Zg==")))
@@ -36,6 +44,21 @@ func TestDecode(t *testing.T) {
 	assert.Equal(t, []byte{0x03, 0xe0, 0x7f}, must(Decode("A+B/")))
 	assert.Equal(t, []byte{0x03, 0xe0, 0x7f}, must(Decode("A-B_")))
 
+	// padding is optional
+	assert.Equal(t, []byte("f"), must(Decode("Zg")))
+	assert.Equal(t, []byte("fo"), must(Decode("Zm8")))
+	assert.Equal(t, []byte{0xfb, 0xff}, must(Decode("-_8")))
+	assert.Equal(t, []byte{0xfb, 0xff}, must(Decode("-_8=")))
+	assert.Equal(t, []byte{0xfb, 0xff}, must(Decode("+/8")))
+
+	// trailing newlines (as output by base64(1)) are ignored
+	assert.Equal(t, []byte("f"), must(Decode("Zg==\n")))
+	assert.Equal(t, []byte("fooba"), must(Decode("Zm9vYmE=\r\n")))
+	assert.Equal(t, []byte("fo"), must(Decode("Zm8\n")))
+
 	_, err := Decode("b.o.g.u.s")
 	require.Error(t, err)
+
+	_, err = Decode("====")
+	require.Error(t, err)
 }
@@ -0,0 +1,124 @@
+package gomplate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/hairyhenderson/gomplate/v4/internal/urlhelpers"
+)
+
+// checkResult - the outcome of reading a single source for Check
+type checkResult struct {
+	err   error
+	u     *url.URL
+	kind  string
+	alias string
+	url   string
+}
+
+// Check reads every datasource, context, and values source in the given
+// configuration, without rendering any templates, and writes a table to w
+// reporting whether each could be read. An error is returned if any could not
+// be read.
+func Check(ctx context.Context, cfg *Config, w io.Writer) error {
+	cfg.applyDefaults()
+
+	ctx = datafs.ContextWithStdin(ctx, cfg.Stdin)
+	if datafs.FSProviderFromContext(ctx) == nil {
+		ctx = datafs.ContextWithFSProvider(ctx, DefaultFSProvider)
+	}
+
+	tr := newRenderer(optionsFromConfig(cfg))
+
+	results := []checkResult{}
+	for _, alias := range sortedAliases(cfg.DataSources) {
+		results = append(results, checkResult{kind: "datasource", alias: alias, u: cfg.DataSources[alias].URL, url: urlhelpers.Redact(cfg.DataSources[alias].URL)})
+	}
+	for _, alias := range sortedAliases(cfg.Context) {
+		results = append(results, checkResult{kind: "context", alias: alias, u: cfg.Context[alias].URL, url: urlhelpers.Redact(cfg.Context[alias].URL)})
+	}
+	for i, alias := range tr.valueAliases {
+		results = append(results, checkResult{kind: "values", alias: alias, u: cfg.Values[i].URL, url: urlhelpers.Redact(cfg.Values[i].URL)})
+	}
+
+	failed := 0
+	for i, r := range results {
+		_, _, err := tr.sr.ReadSource(ctx, r.alias)
+		if err != nil {
+			results[i].err = err
+			failed++
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tALIAS\tURL\tRESULT")
+	for _, r := range results {
+		result := "ok"
+		if r.err != nil {
+			// the error may embed the URL in several forms, so secrets are
+			// masked in the message as a whole
+			result = "FAILED: " + redactSecrets(r.err.Error(), r.u)
+		}
+
+		alias := r.alias
+		if r.kind == "values" {
+			// values are registered with reserved aliases, which aren't
+			// meaningful to users
+			alias = "-"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.kind, alias, r.url, result)
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write check results: %w", err)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d datasources could not be read", failed, len(results))
+	}
+
+	return nil
+}
+
+// redactSecrets masks any password or query parameter values from u that
+// appear in msg, in either raw or escaped form.
+func redactSecrets(msg string, u *url.URL) string {
+	if u == nil {
+		return msg
+	}
+
+	secrets := []string{}
+	if p, ok := u.User.Password(); ok {
+		secrets = append(secrets, p)
+	}
+	for _, vs := range u.Query() {
+		secrets = append(secrets, vs...)
+	}
+
+	for _, v := range secrets {
+		if v == "" {
+			continue
+		}
+		msg = strings.ReplaceAll(msg, v, "xxxxx")
+		msg = strings.ReplaceAll(msg, url.QueryEscape(v), "xxxxx")
+		msg = strings.ReplaceAll(msg, url.PathEscape(v), "xxxxx")
+	}
+
+	return msg
+}
+
+func sortedAliases(m map[string]DataSource) []string {
+	aliases := make([]string, 0, len(m))
+	for alias := range m {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	return aliases
+}
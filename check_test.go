@@ -0,0 +1,64 @@
+package gomplate
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	fsys := datafs.WrapWdFS(fstest.MapFS{
+		"tmp/data.json":   &fstest.MapFile{Data: []byte(`{"foo": "bar"}`)},
+		"tmp/values.yaml": &fstest.MapFile{Data: []byte("name: world\n")},
+	})
+	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file", ""))
+
+	mustParseURL := func(s string) *url.URL {
+		u, err := url.Parse(s)
+		require.NoError(t, err)
+		return u
+	}
+
+	cfg := &Config{
+		DataSources: map[string]DataSource{
+			"data": {URL: mustParseURL("file:///tmp/data.json")},
+		},
+		Context: map[string]DataSource{
+			"ctx": {URL: mustParseURL("file:///tmp/data.json")},
+		},
+		Values: []DataSource{{URL: mustParseURL("file:///tmp/values.yaml")}},
+	}
+
+	out := &bytes.Buffer{}
+	err := Check(ctx, cfg, out)
+	require.NoError(t, err)
+	assert.Equal(t, `KIND        ALIAS  URL                      RESULT
+datasource  data   file:///tmp/data.json    ok
+context     ctx    file:///tmp/data.json    ok
+values      -      file:///tmp/values.yaml  ok
+`, out.String())
+
+	cfg.DataSources["missing"] = DataSource{URL: mustParseURL("file:///tmp/missing.json")}
+
+	out.Reset()
+	err = Check(ctx, cfg, out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 4 datasources could not be read")
+	assert.Contains(t, out.String(), "datasource  missing  file:///tmp/missing.json  FAILED: couldn't read datasource 'missing'")
+	assert.Contains(t, out.String(), "datasource  data     file:///tmp/data.json     ok")
+
+	delete(cfg.DataSources, "missing")
+	cfg.DataSources["secret"] = DataSource{URL: mustParseURL("file:///tmp/missing.json?token=SECRET123")}
+
+	out.Reset()
+	err = Check(ctx, cfg, out)
+	require.Error(t, err)
+	assert.Contains(t, out.String(), "FAILED: couldn't read datasource 'secret'")
+	assert.NotContains(t, out.String(), "SECRET123")
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+	"github.com/hairyhenderson/gomplate/v4/internal/urlhelpers"
 	"github.com/hairyhenderson/yaml"
 )
 
@@ -49,11 +50,20 @@ type Config struct {
 	// currently be set in the config file.
 	ExtraHeaders map[string]http.Header `yaml:"-"`
 
+	// InputFileSources - HTTP options for remote templates given as URLs in
+	// InputFiles, keyed by the URL. Only the Header, ClientCert, and ClientKey
+	// fields are used. Can't currently be set in the config file.
+	InputFileSources map[string]DataSource `yaml:"-"`
+
 	DataSources map[string]DataSource   `yaml:"datasources,omitempty"`
 	Context     map[string]DataSource   `yaml:"context,omitempty"`
 	Templates   map[string]DataSource   `yaml:"templates,omitempty"`
 	Plugins     map[string]PluginConfig `yaml:"plugins,omitempty"`
 
+	// Values - datasources to be read immediately and deep-merged, in order,
+	// into the root of the template's context
+	Values []DataSource `yaml:"values,omitempty"`
+
 	Input                 string   `yaml:"in,omitempty"`
 	InputDir              string   `yaml:"inputDir,omitempty"`
 	InputFiles            []string `yaml:"inputFiles,omitempty,flow"`
@@ -64,6 +74,8 @@ type Config struct {
 	OutputMap   string   `yaml:"outputMap,omitempty"`
 	OutputFiles []string `yaml:"outputFiles,omitempty,flow"`
 	OutMode     string   `yaml:"chmod,omitempty"`
+	InPlace     bool     `yaml:"inPlace,omitempty"`
+	Force       bool     `yaml:"force,omitempty"`
 
 	LDelim string `yaml:"leftDelim,omitempty"`
 	RDelim string `yaml:"rightDelim,omitempty"`
@@ -85,6 +97,8 @@ type rawConfig struct {
 	Templates   config.Templates        `yaml:"templates,omitempty"`
 	Plugins     map[string]PluginConfig `yaml:"plugins,omitempty"`
 
+	Values []string `yaml:"values,omitempty,flow"`
+
 	Input                 string   `yaml:"in,omitempty"`
 	InputDir              string   `yaml:"inputDir,omitempty"`
 	InputFiles            []string `yaml:"inputFiles,omitempty,flow"`
@@ -95,6 +109,8 @@ type rawConfig struct {
 	OutputMap   string   `yaml:"outputMap,omitempty"`
 	OutputFiles []string `yaml:"outputFiles,omitempty,flow"`
 	OutMode     string   `yaml:"chmod,omitempty"`
+	InPlace     bool     `yaml:"inPlace,omitempty"`
+	Force       bool     `yaml:"force,omitempty"`
 
 	LDelim string `yaml:"leftDelim,omitempty"`
 	RDelim string `yaml:"rightDelim,omitempty"`
@@ -119,11 +135,21 @@ func (c *Config) UnmarshalYAML(value *yaml.Node) error {
 		return err
 	}
 
+	var values []DataSource
+	for _, v := range r.Values {
+		u, err := urlhelpers.ParseSourceURL(v)
+		if err != nil {
+			return fmt.Errorf("could not parse values URL %q: %w", v, err)
+		}
+		values = append(values, DataSource{URL: u})
+	}
+
 	*c = Config{
 		DataSources:           r.DataSources,
 		Context:               r.Context,
 		Templates:             r.Templates,
 		Plugins:               r.Plugins,
+		Values:                values,
 		Input:                 r.Input,
 		InputDir:              r.InputDir,
 		InputFiles:            r.InputFiles,
@@ -133,6 +159,8 @@ func (c *Config) UnmarshalYAML(value *yaml.Node) error {
 		OutputMap:             r.OutputMap,
 		OutputFiles:           r.OutputFiles,
 		OutMode:               r.OutMode,
+		InPlace:               r.InPlace,
+		Force:                 r.Force,
 		LDelim:                r.LDelim,
 		RDelim:                r.RDelim,
 		MissingKey:            r.MissingKey,
@@ -149,11 +177,17 @@ func (c *Config) UnmarshalYAML(value *yaml.Node) error {
 //
 // Deprecated: custom unmarshaling will be removed in the next version
 func (c Config) MarshalYAML() (interface{}, error) {
+	var values []string
+	for _, v := range c.Values {
+		values = append(values, v.URL.String())
+	}
+
 	aux := rawConfig{
 		DataSources:           c.DataSources,
 		Context:               c.Context,
 		Templates:             c.Templates,
 		Plugins:               c.Plugins,
+		Values:                values,
 		Input:                 c.Input,
 		InputDir:              c.InputDir,
 		InputFiles:            c.InputFiles,
@@ -163,6 +197,8 @@ func (c Config) MarshalYAML() (interface{}, error) {
 		OutputMap:             c.OutputMap,
 		OutputFiles:           c.OutputFiles,
 		OutMode:               c.OutMode,
+		InPlace:               c.InPlace,
+		Force:                 c.Force,
 		LDelim:                c.LDelim,
 		RDelim:                c.RDelim,
 		MissingKey:            c.MissingKey,
@@ -193,6 +229,10 @@ func mergeDataSources(left, right DataSource) DataSource {
 	if right.URL != nil {
 		left.URL = right.URL
 	}
+	if right.ClientCert != "" {
+		left.ClientCert = right.ClientCert
+		left.ClientKey = right.ClientKey
+	}
 	if left.Header == nil {
 		left.Header = right.Header
 	} else {
@@ -278,16 +318,28 @@ func (c *Config) MergeFrom(o *Config) *Config {
 		c.OutputDir = ""
 		c.OutputFiles = nil
 		c.OutputMap = o.OutputMap
+		c.InPlace = false
 	}
 	if !isZero(o.OutputDir) {
 		c.OutputDir = o.OutputDir
 		c.OutputFiles = nil
 		c.OutputMap = ""
+		c.InPlace = false
 	}
 	if !isZero(o.OutputFiles) {
 		c.OutputDir = ""
 		c.OutputFiles = o.OutputFiles
 		c.OutputMap = ""
+		c.InPlace = false
+	}
+	if !isZero(o.InPlace) {
+		c.InPlace = o.InPlace
+		c.OutputDir = ""
+		c.OutputFiles = nil
+		c.OutputMap = ""
+	}
+	if !isZero(o.Force) {
+		c.Force = o.Force
 	}
 	if !isZero(o.ExecPipe) {
 		c.ExecPipe = o.ExecPipe
@@ -324,6 +376,22 @@ func (c *Config) MergeFrom(o *Config) *Config {
 	} else {
 		c.Context = mergeDataSourceMaps(c.Context, o.Context)
 	}
+	if !isZero(o.Values) {
+		c.Values = append(c.Values, o.Values...)
+	}
+	if len(o.ExtraHeaders) > 0 {
+		if c.ExtraHeaders == nil {
+			c.ExtraHeaders = map[string]http.Header{}
+		}
+		for k, v := range o.ExtraHeaders {
+			c.ExtraHeaders[k] = v
+		}
+	}
+	if c.InputFileSources == nil {
+		c.InputFileSources = o.InputFileSources
+	} else {
+		c.InputFileSources = mergeDataSourceMaps(c.InputFileSources, o.InputFileSources)
+	}
 	if len(o.Plugins) > 0 {
 		for k, v := range o.Plugins {
 			c.Plugins[k] = v
@@ -361,6 +429,23 @@ func (c Config) validate() (err error) {
 	}
 
 	if err == nil {
+		err = notTogether(
+			[]string{"inPlace", "outputFiles", "outputDir", "outputMap", "execPipe"},
+			c.InPlace, c.OutputFiles, c.OutputDir, c.OutputMap, c.ExecPipe)
+	}
+
+	if err == nil {
+		err = mustTogether("inPlace", "inputFiles",
+			c.InPlace, c.InputFiles)
+	}
+
+	if err == nil {
+		if c.InPlace && slices.Contains(c.InputFiles, "-") {
+			err = fmt.Errorf("inPlace can not be used when reading from standard input")
+		}
+	}
+
+	if err == nil && !c.InPlace {
 		f := len(c.InputFiles)
 		if f == 0 && c.Input != "" {
 			f = 1
@@ -383,6 +468,12 @@ func (c Config) validate() (err error) {
 		}
 	}
 
+	if err == nil {
+		if _, ok := c.Context["."]; ok && len(c.Values) > 0 {
+			err = fmt.Errorf("values can not be used when the root context ('.') is set")
+		}
+	}
+
 	if err == nil {
 		missingKeyValues := []string{"", "error", "zero", "default", "invalid"}
 		if !slices.Contains(missingKeyValues, c.MissingKey) {
@@ -423,6 +514,8 @@ func isZero(value interface{}) bool {
 		return v == ""
 	case []string:
 		return len(v) == 0
+	case []DataSource:
+		return len(v) == 0
 	case bool:
 		return !v
 	default:
@@ -449,7 +542,7 @@ func (c *Config) applyDefaults() {
 	if c.Input == "" && c.InputDir == "" && len(c.InputFiles) == 0 {
 		c.InputFiles = []string{"-"}
 	}
-	if c.OutputDir == "" && c.OutputMap == "" && len(c.OutputFiles) == 0 {
+	if c.OutputDir == "" && c.OutputMap == "" && len(c.OutputFiles) == 0 && !c.InPlace {
 		c.OutputFiles = []string{"-"}
 	}
 	if c.LDelim == "" {
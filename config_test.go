@@ -98,6 +98,18 @@ pluginTimeout: 2s
 	cf, err = Parse(strings.NewReader(in))
 	require.NoError(t, err)
 	assert.EqualValues(t, expected, cf)
+
+	in = `values: [values.yaml, https://example.com/values.json]`
+	expected = &Config{
+		Values: []DataSource{
+			{URL: mustURL("values.yaml")},
+			{URL: mustURL("https://example.com/values.json")},
+		},
+	}
+
+	cf, err = Parse(strings.NewReader(in))
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, cf)
 }
 
 func mustURL(s string) *url.URL {
@@ -184,6 +196,38 @@ postExec: [echo]
 execPipe: true
 outputMap: foo
 postExec: [echo]
+`))
+
+	require.NoError(t, validateConfig(`inPlace: true
+inputFiles: [foo, bar]
+`))
+
+	require.Error(t, validateConfig(`inPlace: true
+`))
+
+	require.Error(t, validateConfig(`inPlace: true
+in: foo
+`))
+
+	require.Error(t, validateConfig(`inPlace: true
+inputFiles: ['-']
+`))
+
+	require.Error(t, validateConfig(`inPlace: true
+inputFiles: [foo]
+outputFiles: [bar]
+`))
+
+	require.NoError(t, validateConfig(`values: [values.yaml]
+context:
+  foo:
+    url: foo.json
+`))
+
+	require.Error(t, validateConfig(`values: [values.yaml]
+context:
+  .:
+    url: foo.json
 `))
 }
 
@@ -442,6 +486,34 @@ func TestMergeFrom(t *testing.T) {
 	}
 
 	assert.EqualValues(t, expected, cfg.MergeFrom(other))
+
+	// values are appended, so that later values take precedence
+	cfg = &Config{
+		Values: []DataSource{{URL: mustURL("base.yaml")}},
+	}
+	other = &Config{
+		Values: []DataSource{{URL: mustURL("override.yaml")}},
+	}
+	expected = &Config{
+		Values: []DataSource{
+			{URL: mustURL("base.yaml")},
+			{URL: mustURL("override.yaml")},
+		},
+	}
+
+	assert.EqualValues(t, expected, cfg.MergeFrom(other))
+	// explicit output flags override inPlace from the config file
+	for _, other := range []*Config{
+		{OutputFiles: []string{"out.txt"}},
+		{OutputDir: "out/"},
+		{OutputMap: "out/{{ .in }}"},
+	} {
+		cfg = &Config{
+			InputFiles: []string{"in.txt"},
+			InPlace:    true,
+		}
+		assert.False(t, cfg.MergeFrom(other).InPlace)
+	}
 }
 
 func TestConfig_String(t *testing.T) {
@@ -2,9 +2,11 @@ package gomplate
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"strings"
 
+	"github.com/hairyhenderson/gomplate/v4/coll"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
 )
@@ -22,19 +24,34 @@ func (c *tmplctx) Env() map[string]string {
 	return env
 }
 
-// createTmplContext reads the datasources for the given aliases
+// createTmplContext reads the datasources for the given aliases. The values
+// are read first and deep-merged, in order, into the root of the context
 func createTmplContext(
-	ctx context.Context, aliases []string,
+	ctx context.Context, values, aliases []string,
 	sr datafs.DataSourceReader,
 ) (interface{}, error) {
 	tctx := &tmplctx{}
-	for _, a := range aliases {
-		ct, b, err := sr.ReadSource(ctx, a)
+	for _, v := range values {
+		content, err := readTmplContextSource(ctx, sr, v)
 		if err != nil {
 			return nil, err
 		}
 
-		content, err := parsers.ParseData(ct, string(b))
+		m, ok := content.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("values from %s must be a map, not %T", v, content)
+		}
+
+		// later values take precedence over earlier ones
+		merged, err := coll.Merge(m, *tctx)
+		if err != nil {
+			return nil, err
+		}
+		*tctx = merged
+	}
+
+	for _, a := range aliases {
+		content, err := readTmplContextSource(ctx, sr, a)
 		if err != nil {
 			return nil, err
 		}
@@ -47,3 +64,12 @@ func createTmplContext(
 	}
 	return tctx, nil
 }
+
+func readTmplContextSource(ctx context.Context, sr datafs.DataSourceReader, alias string) (interface{}, error) {
+	ct, b, err := sr.ReadSource(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsers.ParseData(ct, string(b))
+}
@@ -31,7 +31,7 @@ func TestCreateContext(t *testing.T) {
 	reg := datafs.NewRegistry()
 	sr := datafs.NewSourceReader(reg)
 
-	c, err := createTmplContext(ctx, nil, sr)
+	c, err := createTmplContext(ctx, nil, nil, sr)
 	require.NoError(t, err)
 	assert.Empty(t, c)
 
@@ -49,7 +49,7 @@ func TestCreateContext(t *testing.T) {
 	reg.Register(".", DataSource{URL: ub})
 
 	t.Setenv("foo", "foo: bar")
-	c, err = createTmplContext(ctx, []string{"foo"}, sr)
+	c, err = createTmplContext(ctx, nil, []string{"foo"}, sr)
 	require.NoError(t, err)
 	assert.IsType(t, &tmplctx{}, c)
 	tctx := c.(*tmplctx)
@@ -57,9 +57,41 @@ func TestCreateContext(t *testing.T) {
 	assert.Equal(t, "bar", ds["foo"])
 
 	t.Setenv("bar", "bar: baz")
-	c, err = createTmplContext(ctx, []string{"."}, sr)
+	c, err = createTmplContext(ctx, nil, []string{"."}, sr)
 	require.NoError(t, err)
 	assert.IsType(t, map[string]interface{}{}, c)
 	ds = c.(map[string]interface{})
 	assert.Equal(t, "baz", ds["bar"])
 }
+
+func TestCreateContextWithValues(t *testing.T) {
+	fsmux := fsimpl.NewMux()
+	fsmux.Add(datafs.EnvFS)
+
+	ctx := datafs.ContextWithFSProvider(context.Background(), fsmux)
+
+	reg := datafs.NewRegistry()
+	sr := datafs.NewSourceReader(reg)
+
+	for _, name := range []string{"base", "override", "foo", "list"} {
+		u, _ := url.Parse("env:///" + name + "?type=application/yaml")
+		reg.Register(name, DataSource{URL: u})
+	}
+
+	t.Setenv("base", "a: 1\nb:\n  c: 2\n  d: 3\nl: [1, 2]\n")
+	t.Setenv("override", "b:\n  d: 4\nl: [3]\nfoo: overridden\n")
+	t.Setenv("foo", "bar: baz")
+
+	c, err := createTmplContext(ctx, []string{"base", "override"}, []string{"foo"}, sr)
+	require.NoError(t, err)
+	assert.Equal(t, &tmplctx{
+		"a":   1,
+		"b":   map[string]interface{}{"c": 2, "d": 4},
+		"l":   []interface{}{3},
+		"foo": map[string]interface{}{"bar": "baz"},
+	}, c)
+
+	t.Setenv("list", "[1, 2]")
+	_, err = createTmplContext(ctx, []string{"list"}, nil, sr)
+	require.Error(t, err)
+}
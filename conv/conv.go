@@ -59,6 +59,21 @@ func ToBool(in interface{}) bool {
 	}
 }
 
+// ParseBool - parses a string as a boolean, like ToBool, but strictly. The
+// strings "1", "t", "true", "y", "yes", and "on" are true, and "", "0", "f",
+// "false", "n", "no", and "off" are false (any capitalization, ignoring
+// surrounding whitespace). Any other string is an error.
+func ParseBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "t", "true", "y", "yes", "on":
+		return true, nil
+	case "", "0", "f", "false", "n", "no", "off":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid boolean value %q", s)
+	}
+}
+
 // ToBools -
 func ToBools(in ...interface{}) []bool {
 	out := make([]bool, len(in))
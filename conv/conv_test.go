@@ -462,6 +462,25 @@ func TestToBool(t *testing.T) {
 	}
 }
 
+func TestParseBool(t *testing.T) {
+	for _, d := range []string{"1", "t", "T", "true", "True", "y", "yes", "YES", "on", "On", " true\n"} {
+		out, err := ParseBool(d)
+		require.NoError(t, err)
+		assert.True(t, out, d)
+	}
+
+	for _, d := range []string{"", "0", "f", "false", "FALSE", "n", "no", "off", "OFF", " "} {
+		out, err := ParseBool(d)
+		require.NoError(t, err)
+		assert.False(t, out, d)
+	}
+
+	for _, d := range []string{"ture", "2", "0x1", "enabled", "-"} {
+		_, err := ParseBool(d)
+		require.Error(t, err, d)
+	}
+}
+
 func TestDict(t *testing.T) {
 	testdata := []struct {
 		expected map[string]interface{}
@@ -2,6 +2,9 @@
 package env
 
 import (
+	"os"
+	"strings"
+
 	osfs "github.com/hack-pad/hackpadfs/os"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 )
@@ -9,7 +12,8 @@ import (
 // Getenv - retrieves the value of the environment variable named by the key.
 // If the variable is unset, but the same variable ending in `_FILE` is set, the
 // referenced file will be read into the value.
-// Otherwise the provided default (or an emptry string) is returned.
+// Otherwise the provided default (or an emptry string) is returned. Variables
+// that are set to an empty string are treated as unset.
 func Getenv(key string, def ...string) string {
 	fsys := datafs.WrapWdFS(osfs.NewFS())
 	return datafs.GetenvFsys(fsys, key, def...)
@@ -30,3 +34,24 @@ func LookupEnv(key string) (string, bool) {
 	fsys := datafs.WrapWdFS(osfs.NewFS())
 	return datafs.LookupEnvFsys(fsys, key)
 }
+
+// GetAll - returns all environment variables as a map. If a prefix is given,
+// only variables starting with the prefix are returned, with the prefix
+// removed and the remaining name lower-cased (e.g. with the prefix "APP_",
+// APP_DB_HOST becomes db_host). Variables with an empty name after the prefix
+// is removed are skipped.
+func GetAll(prefix string) map[string]string {
+	out := map[string]string{}
+	for _, kv := range os.Environ() {
+		k, v, _ := strings.Cut(kv, "=")
+		if prefix != "" {
+			name, ok := strings.CutPrefix(k, prefix)
+			if !ok || name == "" {
+				continue
+			}
+			k = strings.ToLower(name)
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -11,6 +11,9 @@ func TestGetenv(t *testing.T) {
 	assert.Empty(t, Getenv("FOOBARBAZ"))
 	assert.Equal(t, os.Getenv("USER"), Getenv("USER"))
 	assert.Equal(t, "default value", Getenv("BLAHBLAHBLAH", "default value"))
+
+	t.Setenv("BLAHBLAHBLAH", "")
+	assert.Equal(t, "default value", Getenv("BLAHBLAHBLAH", "default value"))
 }
 
 func TestExpandEnv(t *testing.T) {
@@ -20,3 +23,20 @@ func TestExpandEnv(t *testing.T) {
 	assert.Equal(t, os.Getenv("USER")+": "+os.Getenv("HOME"),
 		ExpandEnv("$USER: ${HOME}"))
 }
+
+func TestGetAll(t *testing.T) {
+	t.Setenv("GETALLTEST_PORT", "8080")
+	t.Setenv("GETALLTEST_DB_HOST", "db.local")
+	t.Setenv("GETALLTEST_", "skipped")
+
+	all := GetAll("")
+	assert.Equal(t, "8080", all["GETALLTEST_PORT"])
+	assert.Equal(t, os.Getenv("HOME"), all["HOME"])
+
+	assert.Equal(t, map[string]string{
+		"port":    "8080",
+		"db_host": "db.local",
+	}, GetAll("GETALLTEST_"))
+
+	assert.Empty(t, GetAll("BOGUSPREFIXTHATISNTSET_"))
+}
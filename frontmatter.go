@@ -0,0 +1,91 @@
+package gomplate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hairyhenderson/gomplate/v4/coll"
+	"github.com/hairyhenderson/yaml"
+)
+
+// frontMatter - per-template options, set in a YAML block fenced by "---"
+// lines at the very top of the template
+type frontMatter struct {
+	Values map[string]interface{} `yaml:"values"`
+	LDelim string                 `yaml:"leftDelim"`
+	RDelim string                 `yaml:"rightDelim"`
+}
+
+const frontMatterFence = "---"
+
+// parseFrontMatter splits the front matter from the template text, returning
+// the remaining body. A leading YAML document is only treated as front matter
+// when it is a map containing nothing but front matter keys, so that templates
+// for multi-document YAML files are left alone. When there is no front matter,
+// fm is nil and the text is returned unchanged.
+func parseFrontMatter(text string) (fm *frontMatter, body string, err error) {
+	first, rest, ok := strings.Cut(text, "\n")
+	if !ok || strings.TrimSuffix(first, "\r") != frontMatterFence {
+		return nil, text, nil
+	}
+
+	var header string
+	found := false
+	for off := 0; !found; {
+		line, next, more := strings.Cut(rest[off:], "\n")
+		if strings.TrimSuffix(line, "\r") == frontMatterFence {
+			header, body, found = rest[:off], next, true
+			continue
+		}
+		if !more {
+			return nil, text, nil
+		}
+		off += len(line) + 1
+	}
+
+	keys := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(header), &keys); err != nil || len(keys) == 0 {
+		return nil, text, nil
+	}
+	for k := range keys {
+		switch k {
+		case "values", "leftDelim", "rightDelim":
+		default:
+			return nil, text, nil
+		}
+	}
+
+	fm = &frontMatter{}
+	if err := yaml.Unmarshal([]byte(header), fm); err != nil {
+		return nil, "", fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	return fm, body, nil
+}
+
+// applyValues returns a copy of the template context with the front matter's
+// values deep-merged into it. Front matter values take precedence, since they
+// are specific to the template.
+func (fm *frontMatter) applyValues(data interface{}) (interface{}, error) {
+	if len(fm.Values) == 0 {
+		return data, nil
+	}
+
+	var m map[string]interface{}
+	switch c := data.(type) {
+	case *tmplctx:
+		m = *c
+	case map[string]interface{}:
+		m = c
+	default:
+		return nil, fmt.Errorf("front matter values can only be used when the template context is a map, not %T", data)
+	}
+
+	merged, err := coll.Merge(fm.Values, m)
+	if err != nil {
+		return nil, err
+	}
+
+	out := tmplctx(merged)
+	return &out, nil
+}
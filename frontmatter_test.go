@@ -0,0 +1,96 @@
+package gomplate
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFrontMatter(t *testing.T) {
+	t.Parallel()
+
+	testdata := []struct {
+		expected *frontMatter
+		in       string
+		body     string
+	}{
+		{nil, "", ""},
+		{nil, "hello world", "hello world"},
+		{nil, "---\nfoo: bar\n", "---\nfoo: bar\n"},
+		// multi-document YAML is not front matter
+		{nil, "---\nfoo: bar\n---\nbaz: qux\n", "---\nfoo: bar\n---\nbaz: qux\n"},
+		{nil, "---\nleftDelim: '[['\nfoo: bar\n---\nbody", "---\nleftDelim: '[['\nfoo: bar\n---\nbody"},
+		{nil, "---\n---\nbody", "---\n---\nbody"},
+		{
+			&frontMatter{LDelim: "[[", RDelim: "]]"},
+			"---\nleftDelim: '[['\nrightDelim: ']]'\n---\n[[ .foo ]]\n",
+			"[[ .foo ]]\n",
+		},
+		{
+			&frontMatter{Values: map[string]interface{}{"foo": "bar"}},
+			"---\r\nvalues:\r\n  foo: bar\r\n---\r\n{{ .foo }}",
+			"{{ .foo }}",
+		},
+		{&frontMatter{LDelim: "<<"}, "---\nleftDelim: '<<'\n---", ""},
+	}
+
+	for _, d := range testdata {
+		fm, body, err := parseFrontMatter(d.in)
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, fm, d.in)
+		assert.Equal(t, d.body, body, d.in)
+	}
+
+	_, _, err := parseFrontMatter("---\nvalues: [1, 2]\n---\nbody")
+	require.Error(t, err)
+}
+
+func TestFrontMatterApplyValues(t *testing.T) {
+	t.Parallel()
+
+	fm := &frontMatter{}
+	in := &tmplctx{"foo": "bar"}
+	out, err := fm.applyValues(in)
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+
+	fm = &frontMatter{Values: map[string]interface{}{
+		"foo": "baz",
+		"obj": map[string]interface{}{"a": 1},
+	}}
+	in = &tmplctx{"foo": "bar", "obj": map[string]interface{}{"b": 2}, "other": true}
+	out, err = fm.applyValues(in)
+	require.NoError(t, err)
+	assert.Equal(t, &tmplctx{
+		"foo":   "baz",
+		"obj":   map[string]interface{}{"a": 1, "b": 2},
+		"other": true,
+	}, out)
+
+	// the original context is unchanged
+	assert.Equal(t, &tmplctx{"foo": "bar", "obj": map[string]interface{}{"b": 2}, "other": true}, in)
+
+	_, err = fm.applyValues([]interface{}{"foo"})
+	require.Error(t, err)
+}
+
+func TestRenderWithFrontMatter(t *testing.T) {
+	tmpl := "---\nleftDelim: '[['\nrightDelim: ']]'\nvalues:\n  name: world\n---\nhello [[ .name ]] {{ raw }}"
+
+	// front matter is only recognized in experimental mode
+	tr := NewRenderer(RenderOptions{})
+	out := &bytes.Buffer{}
+	err := tr.Render(context.Background(), "test", "---\nleftDelim: '[['\n---\n{{ `hi` }}", out)
+	require.NoError(t, err)
+	assert.Equal(t, "---\nleftDelim: '[['\n---\nhi", out.String())
+
+	ctx := config.SetExperimental(context.Background())
+	out = &bytes.Buffer{}
+	err = tr.Render(ctx, "test", tmpl, out)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world {{ raw }}", out.String())
+}
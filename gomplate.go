@@ -106,7 +106,7 @@ func simpleNamer(outDir string) outputNamer {
 
 func mappingNamer(outMap string, tr *renderer) outputNamer {
 	return outputNamerFunc(func(ctx context.Context, inPath string) (string, error) {
-		tcontext, err := createTmplContext(ctx, tr.tctxAliases, tr.sr)
+		tcontext, err := createTmplContext(ctx, tr.valueAliases, tr.tctxAliases, tr.sr)
 		if err != nil {
 			return "", err
 		}
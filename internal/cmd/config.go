@@ -7,8 +7,10 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
+	"sort"
 	"strings"
 	"time"
 
@@ -29,6 +31,7 @@ const (
 // - creates a gomplate.Config from the cobra flags
 // - creates a gomplate.Config from the config file (if present)
 // - merges the two (flags take precedence)
+// - applies environment variables, client certificates, and overrides
 func loadConfig(ctx context.Context, cmd *cobra.Command, args []string) (*gomplate.Config, error) {
 	flagConfig, err := cobraConfig(cmd, args)
 	if err != nil {
@@ -39,6 +42,10 @@ func loadConfig(ctx context.Context, cmd *cobra.Command, args []string) (*gompla
 	if err != nil {
 		return nil, err
 	}
+	err = checkContextConflicts(cfg, flagConfig)
+	if err != nil {
+		return nil, err
+	}
 	if cfg == nil {
 		cfg = flagConfig
 	} else {
@@ -50,6 +57,34 @@ func loadConfig(ctx context.Context, cmd *cobra.Command, args []string) (*gompla
 		return nil, err
 	}
 
+	addInputFileSources(cfg)
+
+	// client certificates are attached only now, so that they can be set for
+	// datasources defined in the config file or the environment
+	certs, err := getStringSlice(cmd, "datasource-client-cert")
+	if err != nil {
+		return nil, err
+	}
+	keys, err := getStringSlice(cmd, "datasource-client-key")
+	if err != nil {
+		return nil, err
+	}
+	err = parseClientCertFlags(cfg, certs, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	// overrides are applied last, so they win over datasources defined in the
+	// config file, flags, and environment variables
+	overrides, err := getStringSlice(cmd, "datasource-override")
+	if err != nil {
+		return nil, err
+	}
+	err = applyDataSourceOverrides(ctx, cfg, overrides)
+	if err != nil {
+		return nil, err
+	}
+
 	cfg.Stdin = cmd.InOrStdin()
 	cfg.Stdout = cmd.OutOrStdout()
 	cfg.Stderr = cmd.ErrOrStderr()
@@ -158,6 +193,14 @@ func cobraConfig(cmd *cobra.Command, args []string) (cfg *gomplate.Config, err e
 	if err != nil {
 		return nil, err
 	}
+	cfg.InPlace, err = getBool(cmd, "in-place")
+	if err != nil {
+		return nil, err
+	}
+	cfg.Force, err = getBool(cmd, "force")
+	if err != nil {
+		return nil, err
+	}
 
 	if len(args) > 0 {
 		cfg.PostExec = args
@@ -207,6 +250,18 @@ func cobraConfig(cmd *cobra.Command, args []string) (cfg *gomplate.Config, err e
 		return nil, err
 	}
 
+	vals, err := getStringSlice(cmd, "values")
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range vals {
+		u, err := urlhelpers.ParseSourceURL(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --values argument (%s): %w", v, err)
+		}
+		cfg.Values = append(cfg.Values, gomplate.DataSource{URL: u})
+	}
+
 	pl, err := getStringSlice(cmd, "plugin")
 	if err != nil {
 		return nil, err
@@ -280,9 +335,107 @@ func applyEnvVars(_ context.Context, cfg *gomplate.Config) (*gomplate.Config, er
 		cfg.RDelim = env.Getenv("GOMPLATE_RIGHT_DELIM")
 	}
 
+	if ds := env.Getenv("GOMPLATE_DATASOURCES"); ds != "" {
+		err := parseDataSourcesEnv(cfg, ds)
+		if err != nil {
+			return nil, fmt.Errorf("GOMPLATE_DATASOURCES set to invalid value: %w", err)
+		}
+	}
+
 	return cfg, nil
 }
 
+// parseDataSourcesEnv - adds datasources from a semicolon-separated list of
+// alias=URL pairs (the format of $GOMPLATE_DATASOURCES). Datasources already
+// defined (by flags or the config file) take precedence.
+func parseDataSourcesEnv(cfg *gomplate.Config, value string) error {
+	for _, d := range strings.Split(value, ";") {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+
+		alias, ds, err := parseDatasourceArg(d)
+		if err != nil {
+			// entries can carry credentials, and the parse error quotes the
+			// entry in full, so only a redacted form of the entry is reported
+			return fmt.Errorf("invalid entry %q: must be an alias=URL pair, or the name of a file in the working directory", redactDataSourceArg(d))
+		}
+
+		if _, ok := cfg.DataSources[alias]; ok {
+			continue
+		}
+
+		// contexts and datasources share a namespace
+		if _, ok := cfg.Context[alias]; ok {
+			return fmt.Errorf("datasource %q conflicts with a context of the same name", alias)
+		}
+
+		if cfg.DataSources == nil {
+			cfg.DataSources = map[string]gomplate.DataSource{}
+		}
+		cfg.DataSources[alias] = ds
+	}
+
+	return nil
+}
+
+// redactDataSourceArg - masks credentials in a datasource argument (in
+// alias=URL form, or a bare URL) so that it can be reported in errors. URLs
+// which can't be parsed are masked entirely.
+func redactDataSourceArg(value string) string {
+	alias, u, ok := strings.Cut(value, "=")
+	if !ok {
+		u = value
+	}
+
+	redacted := "xxxxx"
+	if pu, err := url.Parse(u); err == nil {
+		redacted = urlhelpers.Redact(pu)
+	}
+
+	if !ok {
+		return redacted
+	}
+
+	return alias + "=" + redacted
+}
+
+// applyDataSourceOverrides - replaces datasources and contexts with those given
+// in alias=URL form (the format of --datasource-override). Overrides for aliases
+// which aren't defined are added as datasources, so that datasources defined
+// in templates can be overridden too.
+func applyDataSourceOverrides(ctx context.Context, cfg *gomplate.Config, overrides []string) error {
+	for _, o := range overrides {
+		alias, ds, err := parseDatasourceArg(o)
+		if err != nil {
+			return fmt.Errorf("invalid --datasource-override argument (%s): %w", o, err)
+		}
+
+		overridden := false
+		if old, ok := cfg.Context[alias]; ok {
+			slog.DebugContext(ctx, "overriding context", "alias", alias,
+				"url", urlhelpers.Redact(ds.URL), "previous", urlhelpers.Redact(old.URL))
+			cfg.Context[alias] = ds
+			overridden = true
+		}
+
+		old, ok := cfg.DataSources[alias]
+		if !ok && overridden {
+			continue
+		}
+
+		slog.DebugContext(ctx, "overriding datasource", "alias", alias,
+			"url", urlhelpers.Redact(ds.URL), "previous", urlhelpers.Redact(old.URL))
+		if cfg.DataSources == nil {
+			cfg.DataSources = map[string]gomplate.DataSource{}
+		}
+		cfg.DataSources[alias] = ds
+	}
+
+	return nil
+}
+
 // postExecInput - return the input to be used after the post-exec command. The
 // input config may be modified if ExecPipe is set (OutputFiles is set to "-"),
 // and Stdout is redirected to a pipe.
@@ -357,6 +510,108 @@ func ParseDataSourceFlags(c *gomplate.Config, datasources, contexts, templates,
 	return nil
 }
 
+// checkContextConflicts - contexts and datasources share a namespace, so a
+// --context name can't also be given to a context in the config file, or to
+// any datasource
+func checkContextConflicts(fileCfg, flagCfg *gomplate.Config) error {
+	names := make([]string, 0, len(flagCfg.Context))
+	for k := range flagCfg.Context {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, k := range names {
+		if fileCfg != nil {
+			if _, ok := fileCfg.Context[k]; ok {
+				return fmt.Errorf("invalid --context %q: a context with this name is already defined in the config file", k)
+			}
+		}
+
+		_, inFlags := flagCfg.DataSources[k]
+		inFile := false
+		if fileCfg != nil {
+			_, inFile = fileCfg.DataSources[k]
+		}
+		if inFlags || inFile {
+			return fmt.Errorf("invalid --context %q: conflicts with a datasource of the same name", k)
+		}
+	}
+
+	return nil
+}
+
+// addInputFileSources - remote templates (URLs given with --file) can be
+// given headers and client certificates with the same flags as datasources,
+// using the URL as the alias
+func addInputFileSources(cfg *gomplate.Config) {
+	for _, f := range cfg.InputFiles {
+		u, err := url.Parse(f)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+
+		if cfg.InputFileSources == nil {
+			cfg.InputFileSources = map[string]gomplate.DataSource{}
+		}
+		src := cfg.InputFileSources[f]
+		src.URL = u
+		if h, ok := cfg.ExtraHeaders[f]; ok {
+			src.Header = h
+			delete(cfg.ExtraHeaders, f)
+		}
+		cfg.InputFileSources[f] = src
+	}
+}
+
+// parseClientCertFlags - sets the ClientCert and ClientKey fields of the
+// referenced DataSources, Context datasources, and remote templates, from the
+// alias=path format flags as provided at the command-line
+func parseClientCertFlags(c *gomplate.Config, certs, keys []string) error {
+	set := func(arg string, f func(ds *gomplate.DataSource, p string)) error {
+		alias, p, ok := strings.Cut(arg, "=")
+		if !ok || alias == "" || p == "" {
+			return fmt.Errorf("invalid argument (%s): must be in alias=path form", arg)
+		}
+
+		found := false
+		if d, ok := c.DataSources[alias]; ok {
+			f(&d, p)
+			c.DataSources[alias] = d
+			found = true
+		}
+		if d, ok := c.Context[alias]; ok {
+			f(&d, p)
+			c.Context[alias] = d
+			found = true
+		}
+		if d, ok := c.InputFileSources[alias]; ok {
+			f(&d, p)
+			c.InputFileSources[alias] = d
+			found = true
+		}
+		if !found {
+			return fmt.Errorf("invalid argument (%s): no datasource, context, or remote template named %q", arg, alias)
+		}
+
+		return nil
+	}
+
+	for _, arg := range certs {
+		err := set(arg, func(ds *gomplate.DataSource, p string) { ds.ClientCert = p })
+		if err != nil {
+			return err
+		}
+	}
+	for _, arg := range keys {
+		err := set(arg, func(ds *gomplate.DataSource, p string) { ds.ClientKey = p })
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func parseResources(c *gomplate.Config, datasources, contexts, templates []string) error {
 	for _, d := range datasources {
 		k, ds, err := parseDatasourceArg(d)
@@ -376,6 +631,9 @@ func parseResources(c *gomplate.Config, datasources, contexts, templates []strin
 		if c.Context == nil {
 			c.Context = map[string]gomplate.DataSource{}
 		}
+		if _, ok := c.Context[k]; ok {
+			return fmt.Errorf("invalid argument (%s): context %q is already defined", d, k)
+		}
 		c.Context[k] = ds
 	}
 	for _, t := range templates {
@@ -11,7 +11,17 @@ import (
 	"golang.org/x/term"
 )
 
-func logFormat(out io.Writer) string {
+// logFormats - the supported log formats
+var logFormats = []string{"json", "console", "logfmt", "simple"}
+
+// logFormat returns the log format to use: the given format if set (from the
+// --log-format flag), otherwise $GOMPLATE_LOG_FORMAT, falling back to console
+// for terminals and json otherwise
+func logFormat(out io.Writer, format string) string {
+	if format != "" {
+		return format
+	}
+
 	defaultFormat := "json"
 	if f, ok := out.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
 		defaultFormat = "console"
@@ -60,12 +70,12 @@ func createLogHandler(format string, out io.Writer, level slog.Level) slog.Handl
 	return handler
 }
 
-func initLogger(out io.Writer, level slog.Level) {
+func initLogger(out io.Writer, level slog.Level, format string) {
 	// default to warn level
 	if level == 0 {
 		level = slog.LevelWarn
 	}
 
-	handler := createLogHandler(logFormat(out), out, level)
+	handler := createLogHandler(logFormat(out, format), out, level)
 	slog.SetDefault(slog.New(handler))
 }
@@ -16,13 +16,16 @@ import (
 func TestLogFormat(t *testing.T) {
 	os.Unsetenv("GOMPLATE_LOG_FORMAT")
 
-	assert.Equal(t, "json", logFormat(nil))
+	assert.Equal(t, "json", logFormat(nil, ""))
 	// os.Stdout isn't a terminal when this runs as a unit test...
-	assert.Equal(t, "json", logFormat(os.Stdout))
+	assert.Equal(t, "json", logFormat(os.Stdout, ""))
 
 	t.Setenv("GOMPLATE_LOG_FORMAT", "simple")
-	assert.Equal(t, "simple", logFormat(os.Stdout))
-	assert.Equal(t, "simple", logFormat(&bytes.Buffer{}))
+	assert.Equal(t, "simple", logFormat(os.Stdout, ""))
+	assert.Equal(t, "simple", logFormat(&bytes.Buffer{}, ""))
+
+	// the flag takes precedence over the environment variable
+	assert.Equal(t, "logfmt", logFormat(os.Stdout, "logfmt"))
 }
 
 // a slog handler that strips the 'time' field
@@ -8,6 +8,8 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"slices"
+	"strings"
 
 	"github.com/hairyhenderson/gomplate/v4"
 	"github.com/hairyhenderson/gomplate/v4/env"
@@ -79,7 +81,11 @@ func NewGomplateCmd(stderr io.Writer) *cobra.Command {
 			if v, _ := cmd.Flags().GetBool("verbose"); v {
 				level = slog.LevelDebug
 			}
-			initLogger(stderr, level)
+			format, _ := cmd.Flags().GetString("log-format")
+			if format != "" && !slices.Contains(logFormats, format) {
+				return fmt.Errorf("invalid --log-format %q: must be one of %s", format, strings.Join(logFormats, ", "))
+			}
+			initLogger(stderr, level, format)
 
 			ctx := cmd.Context()
 
@@ -88,6 +94,15 @@ func NewGomplateCmd(stderr io.Writer) *cobra.Command {
 				return err
 			}
 
+			// in check mode, only read the datasources - nothing is rendered
+			// and the post-exec command isn't run
+			if check, _ := cmd.Flags().GetBool("check"); check {
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+
+				return gomplate.Check(ctx, cfg, cmd.OutOrStdout())
+			}
+
 			// get the post-exec reader now as this may modify cfg
 			postExecReader := postExecInput(cfg)
 
@@ -127,8 +142,12 @@ func InitFlags(command *cobra.Command) {
 
 	command.Flags().StringSliceP("datasource", "d", nil, "`datasource` in alias=URL form. Specify multiple times to add multiple sources.")
 	command.Flags().StringSliceP("datasource-header", "H", nil, "HTTP `header` field in 'alias=Name: value' form to be provided on HTTP-based data sources. Multiples can be set.")
+	command.Flags().StringSlice("datasource-client-cert", nil, "TLS client certificate `file` in 'alias=path' form to be presented to HTTPS-based data sources. Multiples can be set.")
+	command.Flags().StringSlice("datasource-client-key", nil, "TLS client key `file` in 'alias=path' form, for use with --datasource-client-cert. Multiples can be set.")
+	command.Flags().StringSlice("datasource-override", nil, "replace the `datasource` or context with the same alias, in alias=URL form, taking precedence over all other definitions. Multiples can be set.")
 
 	command.Flags().StringSliceP("context", "c", nil, "pre-load a `datasource` into the context, in alias=URL form. Use the special alias `.` to set the root context.")
+	command.Flags().StringSlice("values", nil, "pre-load a `datasource` (URL or file path) and merge it into the root context. Specify multiple times to merge multiple sources, with later ones taking precedence.")
 
 	command.Flags().StringSlice("plugin", nil, "plug in an external command as a function in name=path form. Can be specified multiple times")
 
@@ -145,6 +164,8 @@ func InitFlags(command *cobra.Command) {
 	command.Flags().String("output-dir", ".", "`directory` to store the processed templates. Only used for --input-dir")
 	command.Flags().String("output-map", "", "Template `string` to map the input file to an output path")
 	command.Flags().String("chmod", "", "set the mode for output file(s). Omit to inherit from input file(s)")
+	command.Flags().Bool("in-place", false, "replace the input file(s) with the rendered output (alternative to --out, --output-dir, and --output-map)")
+	command.Flags().Bool("force", false, "always write output files, even when their content is unchanged")
 
 	command.Flags().Bool("exec-pipe", false, "pipe the output to the post-run exec command")
 
@@ -156,10 +177,14 @@ func InitFlags(command *cobra.Command) {
 
 	command.Flags().String("missing-key", "error", "Control the behavior during execution if a map is indexed with a key that is not present in the map. error (default) - return an error, zero - fallback to zero value, default/invalid - print <no value>")
 
+	command.Flags().Bool("check", false, "check that every datasource, context, and values source can be read, without rendering any templates")
+
 	command.Flags().Bool("experimental", false, "enable experimental features [$GOMPLATE_EXPERIMENTAL]")
 
 	command.Flags().BoolP("verbose", "V", false, "output extra information about what gomplate is doing")
 
+	command.Flags().String("log-format", "", "format of log messages: json, console, logfmt, or simple [$GOMPLATE_LOG_FORMAT]")
+
 	command.Flags().String("config", defaultConfigFile, "config file (overridden by commandline flags)")
 }
 
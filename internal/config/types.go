@@ -106,14 +106,22 @@ func ExperimentalEnabled(ctx context.Context) bool {
 type DataSource struct {
 	URL    *url.URL    `yaml:"-"`
 	Header http.Header `yaml:"header,omitempty,flow"`
+
+	// ClientCert and ClientKey - paths to a PEM-encoded TLS client certificate
+	// and key to present to HTTPS datasources. The key may be omitted when the
+	// certificate file also contains the key.
+	ClientCert string `yaml:"clientCert,omitempty"`
+	ClientKey  string `yaml:"clientKey,omitempty"`
 }
 
 // UnmarshalYAML - satisfy the yaml.Umarshaler interface - URLs aren't
 // well supported, and anyway we need to do some extra parsing
 func (d *DataSource) UnmarshalYAML(value *yaml.Node) error {
 	type raw struct {
-		Header http.Header
-		URL    string
+		Header     http.Header
+		URL        string
+		ClientCert string `yaml:"clientCert,omitempty"`
+		ClientKey  string `yaml:"clientKey,omitempty"`
 	}
 	r := raw{}
 	err := value.Decode(&r)
@@ -125,8 +133,10 @@ func (d *DataSource) UnmarshalYAML(value *yaml.Node) error {
 		return fmt.Errorf("could not parse datasource URL %q: %w", r.URL, err)
 	}
 	*d = DataSource{
-		URL:    u,
-		Header: r.Header,
+		URL:        u,
+		Header:     r.Header,
+		ClientCert: r.ClientCert,
+		ClientKey:  r.ClientKey,
 	}
 	return nil
 }
@@ -135,12 +145,16 @@ func (d *DataSource) UnmarshalYAML(value *yaml.Node) error {
 // well supported, and anyway we need to do some extra parsing
 func (d DataSource) MarshalYAML() (interface{}, error) {
 	type raw struct {
-		Header http.Header
-		URL    string
+		Header     http.Header
+		URL        string
+		ClientCert string `yaml:"clientCert,omitempty"`
+		ClientKey  string `yaml:"clientKey,omitempty"`
 	}
 	r := raw{
-		URL:    d.URL.String(),
-		Header: d.Header,
+		URL:        d.URL.String(),
+		Header:     d.Header,
+		ClientCert: d.ClientCert,
+		ClientKey:  d.ClientKey,
 	}
 	return r, nil
 }
@@ -2,6 +2,7 @@ package datafs
 
 import (
 	"context"
+	"errors"
 	"io"
 	"io/fs"
 	"os"
@@ -31,11 +32,41 @@ func WithDataSourceRegistryFS(registry Registry, fsys fs.FS) fs.FS {
 type stdinCtxKey struct{}
 
 // ContextWithStdin injects an [io.Reader] into the context, which can be used
-// to override the default stdin.
+// to override the default stdin. Since stdin can only be consumed once, reads
+// after the injected reader is exhausted will fail with [ErrStdinConsumed].
 func ContextWithStdin(ctx context.Context, r io.Reader) context.Context {
+	if _, ok := r.(*onceReader); !ok && r != nil {
+		r = &onceReader{r: r}
+	}
+
 	return context.WithValue(ctx, stdinCtxKey{}, r)
 }
 
+// ErrStdinConsumed is returned when stdin is read after it has already been
+// read to the end - for example when both the template and a datasource are
+// read from stdin.
+var ErrStdinConsumed = errors.New("stdin has already been read, and can only be used once")
+
+// onceReader wraps a reader that can only be consumed once, returning an error
+// instead of io.EOF to subsequent readers, so they don't silently get no data
+type onceReader struct {
+	r        io.Reader
+	consumed bool
+}
+
+func (o *onceReader) Read(p []byte) (int, error) {
+	if o.consumed {
+		return 0, ErrStdinConsumed
+	}
+
+	n, err := o.r.Read(p)
+	if errors.Is(err, io.EOF) {
+		o.consumed = true
+	}
+
+	return n, err
+}
+
 // StdinFromContext returns the io.Reader that should be used for stdin as
 // injected by [ContextWithStdin]. If no reader has been injected, [os.Stdin] is
 // returned.
@@ -1,11 +1,14 @@
 package datafs
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/fs"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -15,6 +18,7 @@ import (
 	"github.com/hairyhenderson/go-fsimpl"
 	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+	"github.com/hairyhenderson/gomplate/v4/internal/urlhelpers"
 )
 
 // typeOverrideParam gets the query parameter used to override the content type
@@ -38,6 +42,10 @@ type DataSourceReader interface {
 	// arguments will return the same content.
 	ReadSource(ctx context.Context, alias string, args ...string) (string, []byte, error)
 
+	// ReadSourceMeta reads the datasource in the same way as ReadSource, and
+	// returns metadata about the read rather than the content.
+	ReadSourceMeta(ctx context.Context, alias string, args ...string) (*SourceMeta, error)
+
 	// contains registry
 	Registry
 }
@@ -50,8 +58,18 @@ type dsReader struct {
 
 // content type mainly for caching
 type content struct {
+	header      http.Header
 	contentType string
 	b           []byte
+	status      int
+}
+
+// SourceMeta - metadata about a datasource read. Status and Header are only
+// set for HTTP-based datasources, and describe the final response.
+type SourceMeta struct {
+	Header      http.Header
+	ContentType string
+	Status      int
 }
 
 func NewSourceReader(reg Registry) DataSourceReader {
@@ -59,11 +77,33 @@ func NewSourceReader(reg Registry) DataSourceReader {
 }
 
 func (d *dsReader) ReadSource(ctx context.Context, alias string, args ...string) (string, []byte, error) {
+	fc, err := d.read(ctx, alias, args...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fc.contentType, fc.b, nil
+}
+
+func (d *dsReader) ReadSourceMeta(ctx context.Context, alias string, args ...string) (*SourceMeta, error) {
+	fc, err := d.read(ctx, alias, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SourceMeta{
+		Header:      fc.header.Clone(),
+		ContentType: fc.contentType,
+		Status:      fc.status,
+	}, nil
+}
+
+func (d *dsReader) read(ctx context.Context, alias string, args ...string) (*content, error) {
 	source, ok := d.Lookup(alias)
 	if !ok {
 		srcURL, err := url.Parse(alias)
 		if err != nil || !srcURL.IsAbs() {
-			return "", nil, fmt.Errorf("undefined datasource '%s': %w", alias, err)
+			return nil, fmt.Errorf("undefined datasource '%s': %w", alias, err)
 		}
 
 		d.Register(alias, config.DataSource{URL: srcURL})
@@ -82,7 +122,10 @@ func (d *dsReader) ReadSource(ctx context.Context, alias string, args ...string)
 	}
 	cached, ok := d.cache[cacheKey]
 	if ok {
-		return cached.contentType, cached.b, nil
+		slog.DebugContext(ctx, "datasource read from cache",
+			"alias", alias, "args", args)
+
+		return cached, nil
 	}
 
 	arg := ""
@@ -91,16 +134,31 @@ func (d *dsReader) ReadSource(ctx context.Context, alias string, args ...string)
 	}
 	u, err := resolveURL(source.URL, arg)
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 
-	fc, err := d.readFileContent(ctx, u, source.Header)
+	client, err := ClientCertHTTPClient(ctx, source)
 	if err != nil {
-		return "", nil, fmt.Errorf("couldn't read datasource '%s' (%s): %w", alias, u, err)
+		return nil, fmt.Errorf("datasource '%s': %w", alias, err)
+	}
+
+	fc, err := d.readFileContent(ctx, u, source.Header, client)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read datasource '%s' (%s): %w", alias, urlhelpers.Redact(u), err)
 	}
 	d.cache[cacheKey] = fc
 
-	return fc.contentType, fc.b, nil
+	// never log the content itself, as it may contain secrets
+	attrs := []interface{}{
+		"alias", alias, "url", urlhelpers.Redact(u),
+		"contentType", fc.contentType, "bytes", len(fc.b),
+	}
+	if fc.status != 0 {
+		attrs = append(attrs, "status", fc.status)
+	}
+	slog.DebugContext(ctx, "datasource read", attrs...)
+
+	return fc, nil
 }
 
 func removeQueryParam(u *url.URL, key string) *url.URL {
@@ -110,7 +168,57 @@ func removeQueryParam(u *url.URL, key string) *url.URL {
 	return u
 }
 
-func (d *dsReader) readFileContent(ctx context.Context, u *url.URL, hdr http.Header) (*content, error) {
+// ClientCertHTTPClient returns an HTTP client which presents the source's
+// TLS client certificate, or nil if no certificate is configured
+func ClientCertHTTPClient(ctx context.Context, source config.DataSource) (*http.Client, error) {
+	if source.ClientCert == "" {
+		if source.ClientKey != "" {
+			return nil, fmt.Errorf("client key %q given without a client certificate", source.ClientKey)
+		}
+		return nil, nil
+	}
+
+	keyFile := source.ClientKey
+	if keyFile == "" {
+		keyFile = source.ClientCert
+	}
+
+	certPEM, err := readLocalFile(ctx, source.ClientCert)
+	if err != nil {
+		return nil, fmt.Errorf("read client certificate: %w", err)
+	}
+
+	keyPEM, err := readLocalFile(ctx, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read client key: %w", err)
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("load client certificate %q and key %q: %w", source.ClientCert, keyFile, err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// readLocalFile reads a file from the local filesystem, using the filesystem
+// provider in the context
+func readLocalFile(ctx context.Context, name string) ([]byte, error) {
+	fsys, err := FSysForPath(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("fsys for path %v: %w", name, err)
+	}
+
+	return fs.ReadFile(fsys, name)
+}
+
+func (d *dsReader) readFileContent(ctx context.Context, u *url.URL, hdr http.Header, client *http.Client) (*content, error) {
 	// possible type hint in the type query param. Contrary to spec, we allow
 	// unescaped '+' characters to make it simpler to provide types like
 	// "application/array+json"
@@ -127,6 +235,11 @@ func (d *dsReader) readFileContent(ctx context.Context, u *url.URL, hdr http.Hea
 		return nil, fmt.Errorf("fsys for path %v: %w", u, err)
 	}
 
+	var rec *responseRecorder
+	if u.Scheme == "http" || u.Scheme == "https" {
+		rec, client = recordResponses(client)
+	}
+
 	u, fname := SplitFSMuxURL(u)
 
 	// need to support absolute paths on local filesystem too
@@ -137,6 +250,9 @@ func (d *dsReader) readFileContent(ctx context.Context, u *url.URL, hdr http.Hea
 
 	fsys = fsimpl.WithContextFS(ctx, fsys)
 	fsys = fsimpl.WithHeaderFS(hdr, fsys)
+	if client != nil {
+		fsys = fsimpl.WithHTTPClientFS(client, fsys)
+	}
 	fsys = WithDataSourceRegistryFS(d.Registry, fsys)
 
 	f, err := fsys.Open(fname)
@@ -180,12 +296,57 @@ func (d *dsReader) readFileContent(ctx context.Context, u *url.URL, hdr http.Hea
 		}
 	}
 
+	if mimeType == "" && u.Scheme == "aws+sm" && isJSONObject(data) {
+		// Secrets Manager has no notion of content types, but key/value
+		// secrets are stored as JSON objects, so we can parse those
+		mimeType = iohelpers.JSONMimetype
+	}
+
 	if mimeType == "" {
 		// default to text/plain
 		mimeType = iohelpers.TextMimetype
 	}
 
-	return &content{contentType: mimeType, b: data}, nil
+	fc := &content{contentType: mimeType, b: data}
+	if rec != nil {
+		fc.status, fc.header = rec.status, rec.header
+	}
+
+	return fc, nil
+}
+
+// responseRecorder - an http.RoundTripper which records the status and headers
+// of the last response, so that they can be exposed as datasource metadata
+type responseRecorder struct {
+	rt     http.RoundTripper
+	header http.Header
+	status int
+}
+
+// recordResponses returns a copy of client (or the default client, if nil)
+// which records responses with the returned recorder
+func recordResponses(client *http.Client) (*responseRecorder, *http.Client) {
+	c := http.Client{}
+	if client != nil {
+		c = *client
+	}
+
+	rec := &responseRecorder{rt: c.Transport}
+	if rec.rt == nil {
+		rec.rt = http.DefaultTransport
+	}
+	c.Transport = rec
+
+	return rec, &c
+}
+
+func (r *responseRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.rt.RoundTrip(req)
+	if resp != nil {
+		r.status, r.header = resp.StatusCode, resp.Header.Clone()
+	}
+
+	return resp, err
 }
 
 // COPIED FROM /data/datasource.go
@@ -248,3 +409,9 @@ func resolveURL(base *url.URL, rel string) (*url.URL, error) {
 
 	return out, nil
 }
+
+// isJSONObject returns true if b is a valid JSON object
+func isJSONObject(b []byte) bool {
+	b = bytes.TrimSpace(b)
+	return len(b) > 0 && b[0] == '{' && json.Valid(b)
+}
@@ -1,7 +1,16 @@
 package datafs
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -9,6 +18,7 @@ import (
 	"runtime"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	"github.com/hairyhenderson/go-fsimpl"
 	"github.com/hairyhenderson/go-fsimpl/httpfs"
@@ -99,17 +109,41 @@ func TestReadFileContent(t *testing.T) {
 	reg := NewRegistry()
 	sr := &dsReader{Registry: reg}
 
-	fc, err := sr.readFileContent(ctx, mustParseURL("file:///foo.json"), nil)
+	fc, err := sr.readFileContent(ctx, mustParseURL("file:///foo.json"), nil, nil)
 	require.NoError(t, err)
 	assert.Equal(t, []byte(`{"foo": "bar"}`), fc.b)
 
-	fc, err = sr.readFileContent(ctx, mustParseURL("dir/"), nil)
+	fc, err = sr.readFileContent(ctx, mustParseURL("dir/"), nil, nil)
 	require.NoError(t, err)
 	assert.JSONEq(t, `["1.yaml", "2.yaml", "sub"]`, string(fc.b))
 
-	fc, err = sr.readFileContent(ctx, mustParseURL(srv.URL+"/foo.json"), nil)
+	fc, err = sr.readFileContent(ctx, mustParseURL(srv.URL+"/foo.json"), nil, nil)
 	require.NoError(t, err)
 	assert.Equal(t, []byte(`{"foo": "bar"}`), fc.b)
+
+	// Secrets Manager secrets containing JSON objects are parsed as JSON
+	smfsys := WrapWdFS(fstest.MapFS{
+		"kvsecret":    &fstest.MapFile{Data: []byte(` {"user": "dave", "pass": "foo"}` + "\n")},
+		"plainsecret": &fstest.MapFile{Data: []byte(`super-secret`)},
+		"listsecret":  &fstest.MapFile{Data: []byte(`["a", "b"]`)},
+	})
+	fsp.Add(WrappedFSProvider(smfsys, "aws+sm", ""))
+
+	fc, err = sr.readFileContent(ctx, mustParseURL("aws+sm:///kvsecret"), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.JSONMimetype, fc.contentType)
+
+	fc, err = sr.readFileContent(ctx, mustParseURL("aws+sm:///plainsecret"), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.TextMimetype, fc.contentType)
+
+	fc, err = sr.readFileContent(ctx, mustParseURL("aws+sm:///listsecret"), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.TextMimetype, fc.contentType)
+
+	fc, err = sr.readFileContent(ctx, mustParseURL("aws+sm:///plainsecret?type=application/json"), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.JSONMimetype, fc.contentType)
 }
 
 func TestDatasource(t *testing.T) {
@@ -160,3 +194,123 @@ func TestDatasource(t *testing.T) {
 	_, _, err = d.ReadSource(ctx, "bar")
 	require.Error(t, err)
 }
+
+func TestReadSourceLogging(t *testing.T) {
+	buf := &bytes.Buffer{}
+	orig := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(orig) })
+
+	fsys := WrapWdFS(fstest.MapFS{
+		"tmp/secret.txt": &fstest.MapFile{Data: []byte("hunter2")},
+	})
+	ctx := ContextWithFSProvider(context.Background(), WrappedFSProvider(fsys, "file", ""))
+
+	reg := NewRegistry()
+	reg.Register("secret", config.DataSource{URL: mustParseURL("file:///tmp/secret.txt")})
+	d := &dsReader{Registry: reg}
+
+	_, _, err := d.ReadSource(ctx, "secret")
+	require.NoError(t, err)
+	_, _, err = d.ReadSource(ctx, "secret")
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, `"msg":"datasource read","alias":"secret","url":"file:///tmp/secret.txt"`)
+	assert.Contains(t, out, `"bytes":7`)
+	assert.Contains(t, out, `"msg":"datasource read from cache","alias":"secret"`)
+	assert.NotContains(t, out, "hunter2")
+	assert.NotContains(t, out, `"status"`)
+
+	// HTTP datasources also log the response status, and query parameters
+	// are masked
+	mux := http.NewServeMux()
+	mux.HandleFunc("/data.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", iohelpers.JSONMimetype)
+		w.Write([]byte(`{"foo": "bar"}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	fsp := fsimpl.NewMux()
+	fsp.Add(httpfs.FS)
+	ctx = ContextWithFSProvider(context.Background(), fsp)
+
+	reg.Register("api", config.DataSource{URL: mustParseURL(srv.URL + "/data.json?token=s.hunter2")})
+
+	buf.Reset()
+	_, _, err = d.ReadSource(ctx, "api")
+	require.NoError(t, err)
+
+	out = buf.String()
+	assert.Contains(t, out, `"url":"`+srv.URL+`/data.json?token=xxxxx"`)
+	assert.Contains(t, out, `"status":200`)
+	assert.NotContains(t, out, "hunter2")
+}
+
+// selfSignedCert generates a PEM-encoded self-signed certificate and key
+func selfSignedCert(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "gomplate-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return certPEM, keyPEM
+}
+
+func TestClientCertHTTPClient(t *testing.T) {
+	certPEM, keyPEM := selfSignedCert(t)
+
+	fsys := WrapWdFS(fstest.MapFS{
+		"certs/client.crt": &fstest.MapFile{Data: certPEM},
+		"certs/client.key": &fstest.MapFile{Data: keyPEM},
+		"certs/client.pem": &fstest.MapFile{Data: append(append([]byte{}, certPEM...), keyPEM...)},
+	})
+	ctx := ContextWithFSProvider(context.Background(), WrappedFSProvider(fsys, "file", ""))
+
+	client, err := ClientCertHTTPClient(ctx, config.DataSource{})
+	require.NoError(t, err)
+	assert.Nil(t, client)
+
+	_, err = ClientCertHTTPClient(ctx, config.DataSource{ClientKey: "/certs/client.key"})
+	require.Error(t, err)
+
+	client, err = ClientCertHTTPClient(ctx, config.DataSource{
+		ClientCert: "/certs/client.crt",
+		ClientKey:  "/certs/client.key",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, client)
+
+	transport, ok := client.Transport.(*http.Transport)
+	require.True(t, ok)
+	require.Len(t, transport.TLSClientConfig.Certificates, 1)
+
+	// the key can be bundled in the certificate file
+	client, err = ClientCertHTTPClient(ctx, config.DataSource{ClientCert: "/certs/client.pem"})
+	require.NoError(t, err)
+	require.NotNil(t, client)
+
+	_, err = ClientCertHTTPClient(ctx, config.DataSource{ClientCert: "/certs/missing.crt"})
+	require.Error(t, err)
+
+	_, err = ClientCertHTTPClient(ctx, config.DataSource{ClientCert: "/certs/client.crt"})
+	require.Error(t, err)
+}
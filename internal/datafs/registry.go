@@ -1,8 +1,10 @@
 package datafs
 
 import (
+	"fmt"
 	"net/http"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/hairyhenderson/gomplate/v4/internal/config"
@@ -22,6 +24,15 @@ type Registry interface {
 	AddExtraHeader(alias string, hdr http.Header)
 }
 
+// reservedAliasPrefix - starts aliases reserved for datasources registered
+// internally, so that they can't collide with user-defined aliases
+const reservedAliasPrefix = "\x00"
+
+// ValuesAlias returns the reserved alias for the i-th values source
+func ValuesAlias(i int) string {
+	return fmt.Sprintf("%svalues:%d", reservedAliasPrefix, i)
+}
+
 func NewRegistry() Registry {
 	return &dsRegistry{
 		RWMutex:      &sync.RWMutex{},
@@ -63,13 +74,16 @@ func (r *dsRegistry) Lookup(alias string) (config.DataSource, bool) {
 	return ds, ok
 }
 
-// List registered datasource aliases
+// List registered datasource aliases. Reserved aliases aren't listed.
 func (r *dsRegistry) List() []string {
 	r.RLock()
 	defer r.RUnlock()
 
 	keys := make([]string, 0, len(r.m))
 	for k := range r.m {
+		if strings.HasPrefix(k, reservedAliasPrefix) {
+			continue
+		}
 		keys = append(keys, k)
 	}
 
@@ -28,11 +28,15 @@ func TestDefaultRegistry_List(t *testing.T) {
 	reg.Register("b", ds)
 	reg.Register("c", ds)
 	reg.Register("d", ds)
+	reg.Register(ValuesAlias(0), ds)
 
 	actual := reg.List()
 
-	// list must be sorted
+	// list must be sorted, and reserved aliases aren't listed
 	require.Equal(t, []string{"a", "b", "c", "d"}, actual)
+
+	_, ok := reg.Lookup(ValuesAlias(0))
+	require.True(t, ok)
 }
 
 func TestDefaultRegistry_AddExtraHeader(t *testing.T) {
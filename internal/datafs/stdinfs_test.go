@@ -101,6 +101,31 @@ func TestStdinFS(t *testing.T) {
 	require.ErrorIs(t, err, io.EOF)
 }
 
+func TestStdinFS_ReadTwice(t *testing.T) {
+	ctx := ContextWithStdin(context.Background(), bytes.NewReader([]byte("hello")))
+
+	// wrapping again must not reset the consumed state
+	ctx = ContextWithStdin(ctx, StdinFromContext(ctx))
+
+	fsys, err := NewStdinFS(nil)
+	require.NoError(t, err)
+
+	fsys = fsimpl.WithContextFS(ctx, fsys)
+
+	b, err := fs.ReadFile(fsys, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+
+	_, err = fs.ReadFile(fsys, "bar")
+	require.ErrorIs(t, err, ErrStdinConsumed)
+
+	f, err := fsys.Open("bar")
+	require.NoError(t, err)
+
+	_, err = f.Stat()
+	require.ErrorIs(t, err, ErrStdinConsumed)
+}
+
 type errorReader struct {
 	err error
 }
@@ -5,20 +5,33 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/hairyhenderson/go-fsimpl/vaultfs/vaultauth"
 	"github.com/hairyhenderson/gomplate/v4/internal/deprecated"
 	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
 	"github.com/hashicorp/vault/api"
+	"github.com/hashicorp/vault/api/auth/approle"
 	"github.com/hashicorp/vault/api/auth/aws"
+	"github.com/hashicorp/vault/api/auth/userpass"
 )
 
 // compositeVaultAuthMethod configures the auth method based on environment
-// variables. It extends [vaultfs.EnvAuthMethod] by falling back to AWS EC2
-// authentication if the other methods fail.
+// variables. It is equivalent to [vaultauth.EnvAuthMethod], except that secrets
+// can also be read from files named by the corresponding `_FILE` variables,
+// and it falls back to AWS authentication if the other methods fail.
 func compositeVaultAuthMethod(envFsys fs.FS) api.AuthMethod {
 	return vaultauth.CompositeAuthMethod(
-		vaultauth.EnvAuthMethod(),
+		envAppRoleAdapter(envFsys),
+		envGitHubAdapter(envFsys),
+		envUserPassAdapter(envFsys),
+		envLDAPAdapter(envFsys),
+		envK8sAuthAdapter(envFsys),
+		envJWTAuthAdapter(envFsys),
+		envTokenAdapter(envFsys),
+		envIAMAuthAdapter(envFsys),
 		envEC2AuthAdapter(envFsys),
 	)
 }
@@ -27,9 +40,265 @@ func compositeVaultAuthMethod(envFsys fs.FS) api.AuthMethod {
 // 	return compositeVaultAuthMethod(WrapWdFS(osfs.NewFS()))
 // }
 
+// envAppRoleAdapter builds an AppRole authentication method from environment
+// variables, for use only with [compositeVaultAuthMethod]
+func envAppRoleAdapter(envFS fs.FS) api.AuthMethod {
+	roleID := GetenvFsys(envFS, "VAULT_ROLE_ID")
+	secretID := GetenvFsys(envFS, "VAULT_SECRET_ID")
+	if roleID == "" || secretID == "" {
+		return nil
+	}
+
+	mountPath := GetenvFsys(envFS, "VAULT_AUTH_APPROLE_MOUNT", "approle")
+
+	a, err := approle.NewAppRoleAuth(roleID,
+		&approle.SecretID{FromString: secretID},
+		approle.WithMountPath(mountPath),
+	)
+	if err != nil {
+		return nil
+	}
+
+	return a
+}
+
+// envGitHubAdapter builds a GitHub authentication method from environment
+// variables, for use only with [compositeVaultAuthMethod]
+func envGitHubAdapter(envFS fs.FS) api.AuthMethod {
+	token := GetenvFsys(envFS, "VAULT_AUTH_GITHUB_TOKEN")
+	if token == "" {
+		return nil
+	}
+
+	mountPath := GetenvFsys(envFS, "VAULT_AUTH_GITHUB_MOUNT", "github")
+
+	a, err := vaultauth.NewGitHubAuth(
+		&vaultauth.GitHubToken{FromString: token},
+		vaultauth.WithGitHubMountPath(mountPath),
+	)
+	if err != nil {
+		return nil
+	}
+
+	return a
+}
+
+// envUserPassAdapter builds a userpass authentication method from environment
+// variables, for use only with [compositeVaultAuthMethod]
+func envUserPassAdapter(envFS fs.FS) api.AuthMethod {
+	username := GetenvFsys(envFS, "VAULT_AUTH_USERNAME")
+	password := GetenvFsys(envFS, "VAULT_AUTH_PASSWORD")
+	if username == "" || password == "" {
+		return nil
+	}
+
+	mountPath := GetenvFsys(envFS, "VAULT_AUTH_USERPASS_MOUNT", "userpass")
+
+	a, err := userpass.NewUserpassAuth(username,
+		&userpass.Password{FromString: password},
+		userpass.WithMountPath(mountPath),
+	)
+	if err != nil {
+		return nil
+	}
+
+	return a
+}
+
+// envLDAPAdapter builds an LDAP authentication method from environment
+// variables, for use only with [compositeVaultAuthMethod]
+func envLDAPAdapter(envFS fs.FS) api.AuthMethod {
+	username := GetenvFsys(envFS, "VAULT_AUTH_LDAP_USERNAME")
+	password := GetenvFsys(envFS, "VAULT_AUTH_LDAP_PASSWORD")
+	if username == "" || password == "" {
+		return nil
+	}
+
+	return &ldapAuthMethod{
+		username:  username,
+		password:  password,
+		mountPath: GetenvFsys(envFS, "VAULT_AUTH_LDAP_MOUNT", "ldap"),
+	}
+}
+
+// ldapAuthMethod authenticates with Vault's ldap auth method
+type ldapAuthMethod struct {
+	username  string
+	password  string
+	mountPath string
+}
+
+func (a *ldapAuthMethod) Login(ctx context.Context, client *api.Client) (*api.Secret, error) {
+	p := path.Join("auth", a.mountPath, "login", a.username)
+
+	secret, err := client.Logical().WriteWithContext(ctx, p, map[string]interface{}{
+		"password": a.password,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ldap login failed: %w", err)
+	}
+
+	return secret, nil
+}
+
+// envK8sAuthAdapter builds a Kubernetes authentication method from environment
+// variables, for use only with [compositeVaultAuthMethod]
+func envK8sAuthAdapter(envFS fs.FS) api.AuthMethod {
+	role := GetenvFsys(envFS, "VAULT_AUTH_K8S_ROLE")
+	if role == "" {
+		return nil
+	}
+
+	return &k8sAuthMethod{
+		fsys:      envFS,
+		role:      role,
+		mountPath: GetenvFsys(envFS, "VAULT_AUTH_K8S_MOUNT", "kubernetes"),
+		tokenPath: GetenvFsys(envFS, "VAULT_AUTH_K8S_TOKEN_PATH",
+			"/var/run/secrets/kubernetes.io/serviceaccount/token"),
+	}
+}
+
+// k8sAuthMethod authenticates with Vault's kubernetes auth method, using the
+// pod's service account token
+type k8sAuthMethod struct {
+	fsys      fs.FS
+	role      string
+	mountPath string
+	tokenPath string
+}
+
+func (a *k8sAuthMethod) Login(ctx context.Context, client *api.Client) (*api.Secret, error) {
+	jwt, err := readFile(a.fsys, a.tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading service account token: %w", err)
+	}
+
+	p := path.Join("auth", a.mountPath, "login")
+
+	secret, err := client.Logical().WriteWithContext(ctx, p, map[string]interface{}{
+		"role": a.role,
+		"jwt":  strings.TrimSpace(jwt),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes login failed: %w", err)
+	}
+
+	return secret, nil
+}
+
+// envJWTAuthAdapter builds a JWT/OIDC authentication method from environment
+// variables, for use only with [compositeVaultAuthMethod]
+func envJWTAuthAdapter(envFS fs.FS) api.AuthMethod {
+	jwt := GetenvFsys(envFS, "VAULT_AUTH_JWT")
+	role := GetenvFsys(envFS, "VAULT_AUTH_JWT_ROLE")
+	if jwt == "" || role == "" {
+		return nil
+	}
+
+	return &jwtAuthMethod{
+		jwt:       strings.TrimSpace(jwt),
+		role:      role,
+		mountPath: GetenvFsys(envFS, "VAULT_AUTH_JWT_MOUNT", "jwt"),
+	}
+}
+
+// jwtAuthMethod authenticates with Vault's jwt auth method, using a signed
+// token such as one issued to a CI pipeline
+type jwtAuthMethod struct {
+	jwt       string
+	role      string
+	mountPath string
+}
+
+func (a *jwtAuthMethod) Login(ctx context.Context, client *api.Client) (*api.Secret, error) {
+	p := path.Join("auth", a.mountPath, "login")
+
+	secret, err := client.Logical().WriteWithContext(ctx, p, map[string]interface{}{
+		"role": a.role,
+		"jwt":  a.jwt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("jwt login failed: %w", err)
+	}
+
+	return secret, nil
+}
+
+// envTokenAdapter builds a token authentication method from the $VAULT_TOKEN
+// environment variable, falling back to the token stored in ~/.vault-token by
+// the Vault CLI, for use only with [compositeVaultAuthMethod]
+func envTokenAdapter(envFS fs.FS) api.AuthMethod {
+	token := GetenvFsys(envFS, "VAULT_TOKEN")
+	if token == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+
+		token, err = readFile(envFS, filepath.Join(homeDir, ".vault-token"))
+		if err != nil {
+			return nil
+		}
+
+		token = strings.TrimSpace(token)
+	}
+
+	if token == "" {
+		return nil
+	}
+
+	return vaultauth.NewTokenAuth(token)
+}
+
+// useAWSIAMAuth returns true when the AWS auth method should use the iam auth
+// type rather than the default ec2 type
+func useAWSIAMAuth(envFS fs.FS) bool {
+	return strings.EqualFold(GetenvFsys(envFS, "VAULT_AUTH_AWS_TYPE"), "iam")
+}
+
+// envIAMAuthAdapter builds an AWS IAM authentication method from environment
+// variables, for use only with [compositeVaultAuthMethod]. The signed
+// sts:GetCallerIdentity request is built from the ambient AWS credentials.
+func envIAMAuthAdapter(envFS fs.FS) api.AuthMethod {
+	if !useAWSIAMAuth(envFS) {
+		return nil
+	}
+
+	role := GetenvFsys(envFS, "VAULT_AUTH_AWS_ROLE")
+	if role == "" {
+		return nil
+	}
+
+	opts := []aws.LoginOption{
+		aws.WithIAMAuth(),
+		aws.WithMountPath(GetenvFsys(envFS, "VAULT_AUTH_AWS_MOUNT", "aws")),
+		aws.WithRole(role),
+	}
+
+	region := GetenvFsys(envFS, "AWS_REGION", GetenvFsys(envFS, "AWS_DEFAULT_REGION"))
+	if region != "" {
+		opts = append(opts, aws.WithRegion(region))
+	}
+
+	if hdr := GetenvFsys(envFS, "VAULT_AUTH_AWS_HEADER_VALUE"); hdr != "" {
+		opts = append(opts, aws.WithIAMServerIDHeader(hdr))
+	}
+
+	awsauth, err := aws.NewAWSAuth(opts...)
+	if err != nil {
+		return nil
+	}
+
+	return awsauth
+}
+
 // envEC2AuthAdapter builds an AWS EC2 authentication method from environment
-// variables, for use only with [CompositeVaultAuthMethod]
+// variables, for use only with [compositeVaultAuthMethod]
 func envEC2AuthAdapter(envFS fs.FS) api.AuthMethod {
+	if useAWSIAMAuth(envFS) {
+		return nil
+	}
+
 	mountPath := GetenvFsys(envFS, "VAULT_AUTH_AWS_MOUNT", "aws")
 
 	nonce := GetenvFsys(envFS, "VAULT_AUTH_AWS_NONCE")
@@ -0,0 +1,257 @@
+package datafs
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loginServer runs a fake Vault server which responds to logins at loginPath
+// with a client token, and records the login request body in body
+func loginServer(t *testing.T, loginPath string, body map[string]interface{}) *api.Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /v1/"+loginPath, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"auth":{"client_token":"sometoken"}}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client, err := api.NewClient(&api.Config{Address: srv.URL})
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestEnvAppRoleAdapter(t *testing.T) {
+	fsys := fs.FS(fstest.MapFS{
+		"tmp":        &fstest.MapFile{Mode: fs.ModeDir | 0o777},
+		"tmp/secret": &fstest.MapFile{Data: []byte("bar\n")},
+	})
+	fsys = WrapWdFS(fsys)
+
+	t.Setenv("VAULT_ROLE_ID", "")
+	t.Setenv("VAULT_SECRET_ID", "")
+	assert.Nil(t, envAppRoleAdapter(fsys))
+
+	t.Setenv("VAULT_ROLE_ID", "foo")
+	assert.Nil(t, envAppRoleAdapter(fsys))
+
+	t.Setenv("VAULT_SECRET_ID", "bar")
+	require.NotNil(t, envAppRoleAdapter(fsys))
+
+	t.Setenv("VAULT_SECRET_ID", "")
+	t.Setenv("VAULT_SECRET_ID_FILE", "/tmp/secret")
+	require.NotNil(t, envAppRoleAdapter(fsys))
+}
+
+func TestEnvGitHubAdapter(t *testing.T) {
+	t.Setenv("VAULT_AUTH_GITHUB_TOKEN", "")
+	assert.Nil(t, envGitHubAdapter(fstest.MapFS{}))
+
+	t.Setenv("VAULT_AUTH_GITHUB_TOKEN", "foo")
+	assert.NotNil(t, envGitHubAdapter(fstest.MapFS{}))
+}
+
+func TestEnvUserPassAdapter(t *testing.T) {
+	t.Setenv("VAULT_AUTH_USERNAME", "")
+	t.Setenv("VAULT_AUTH_PASSWORD", "")
+	assert.Nil(t, envUserPassAdapter(fstest.MapFS{}))
+
+	t.Setenv("VAULT_AUTH_USERNAME", "dave")
+	assert.Nil(t, envUserPassAdapter(fstest.MapFS{}))
+
+	t.Setenv("VAULT_AUTH_PASSWORD", "foo")
+	assert.NotNil(t, envUserPassAdapter(fstest.MapFS{}))
+}
+
+func TestEnvLDAPAdapter(t *testing.T) {
+	fsys := fs.FS(fstest.MapFS{
+		"tmp/password": &fstest.MapFile{Data: []byte("filepass")},
+	})
+	fsys = WrapWdFS(fsys)
+
+	t.Setenv("VAULT_AUTH_LDAP_USERNAME", "")
+	t.Setenv("VAULT_AUTH_LDAP_PASSWORD", "")
+	assert.Nil(t, envLDAPAdapter(fsys))
+
+	t.Setenv("VAULT_AUTH_LDAP_USERNAME", "dave")
+	assert.Nil(t, envLDAPAdapter(fsys))
+
+	t.Setenv("VAULT_AUTH_LDAP_PASSWORD", "foo")
+	a := envLDAPAdapter(fsys)
+	require.NotNil(t, a)
+
+	body := map[string]interface{}{}
+	client := loginServer(t, "auth/ldap/login/dave", body)
+
+	secret, err := a.Login(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, "sometoken", secret.Auth.ClientToken)
+	assert.Equal(t, map[string]interface{}{"password": "foo"}, body)
+
+	t.Setenv("VAULT_AUTH_LDAP_PASSWORD", "")
+	t.Setenv("VAULT_AUTH_LDAP_PASSWORD_FILE", "/tmp/password")
+	t.Setenv("VAULT_AUTH_LDAP_MOUNT", "corp")
+	a = envLDAPAdapter(fsys)
+	require.NotNil(t, a)
+
+	body = map[string]interface{}{}
+	client = loginServer(t, "auth/corp/login/dave", body)
+
+	_, err = a.Login(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"password": "filepass"}, body)
+}
+
+func TestEnvTokenAdapter(t *testing.T) {
+	fsys := fs.FS(fstest.MapFS{
+		"home/dave":              &fstest.MapFile{Mode: fs.ModeDir | 0o777},
+		"home/dave/.vault-token": &fstest.MapFile{Data: []byte(" sometoken\n")},
+		"home/empty":             &fstest.MapFile{Mode: fs.ModeDir | 0o777},
+		"run/secrets/token":      &fstest.MapFile{Data: []byte("filetoken\n")},
+	})
+	fsys = WrapWdFS(fsys)
+
+	t.Setenv("VAULT_TOKEN", "")
+	t.Setenv("VAULT_TOKEN_FILE", "")
+	t.Setenv("HOME", "/home/empty")
+	assert.Nil(t, envTokenAdapter(fsys))
+
+	t.Setenv("HOME", "/home/dave")
+	a := envTokenAdapter(fsys)
+	require.NotNil(t, a)
+
+	secret, err := a.Login(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "sometoken", secret.Auth.ClientToken)
+
+	// VAULT_TOKEN_FILE takes precedence over ~/.vault-token
+	t.Setenv("VAULT_TOKEN_FILE", "/run/secrets/token")
+	a = envTokenAdapter(fsys)
+	require.NotNil(t, a)
+
+	secret, err = a.Login(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "filetoken", secret.Auth.ClientToken)
+
+	// and VAULT_TOKEN takes precedence over both
+	t.Setenv("VAULT_TOKEN", "envtoken")
+	a = envTokenAdapter(fsys)
+	require.NotNil(t, a)
+
+	secret, err = a.Login(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "envtoken", secret.Auth.ClientToken)
+}
+
+func TestEnvK8sAuthAdapter(t *testing.T) {
+	fsys := fs.FS(fstest.MapFS{
+		"var/run/secrets/kubernetes.io/serviceaccount/token": &fstest.MapFile{Data: []byte("satoken\n")},
+		"tmp/token": &fstest.MapFile{Data: []byte("othertoken")},
+	})
+	fsys = WrapWdFS(fsys)
+
+	t.Setenv("VAULT_AUTH_K8S_ROLE", "")
+	assert.Nil(t, envK8sAuthAdapter(fsys))
+
+	t.Setenv("VAULT_AUTH_K8S_ROLE", "myrole")
+	a := envK8sAuthAdapter(fsys)
+	require.NotNil(t, a)
+
+	body := map[string]interface{}{}
+	client := loginServer(t, "auth/kubernetes/login", body)
+
+	secret, err := a.Login(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, "sometoken", secret.Auth.ClientToken)
+	assert.Equal(t, map[string]interface{}{"role": "myrole", "jwt": "satoken"}, body)
+
+	t.Setenv("VAULT_AUTH_K8S_MOUNT", "k8s")
+	t.Setenv("VAULT_AUTH_K8S_TOKEN_PATH", "/tmp/token")
+	a = envK8sAuthAdapter(fsys)
+	require.NotNil(t, a)
+
+	body = map[string]interface{}{}
+	client = loginServer(t, "auth/k8s/login", body)
+
+	_, err = a.Login(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"role": "myrole", "jwt": "othertoken"}, body)
+
+	t.Setenv("VAULT_AUTH_K8S_TOKEN_PATH", "/tmp/missing")
+	a = envK8sAuthAdapter(fsys)
+	_, err = a.Login(context.Background(), client)
+	require.Error(t, err)
+}
+
+func TestEnvJWTAuthAdapter(t *testing.T) {
+	fsys := fs.FS(fstest.MapFS{
+		"run/secrets/jwt": &fstest.MapFile{Data: []byte("filejwt\n")},
+	})
+	fsys = WrapWdFS(fsys)
+
+	t.Setenv("VAULT_AUTH_JWT", "")
+	t.Setenv("VAULT_AUTH_JWT_ROLE", "")
+	assert.Nil(t, envJWTAuthAdapter(fsys))
+
+	t.Setenv("VAULT_AUTH_JWT", "somejwt")
+	assert.Nil(t, envJWTAuthAdapter(fsys))
+
+	t.Setenv("VAULT_AUTH_JWT_ROLE", "ci")
+	a := envJWTAuthAdapter(fsys)
+	require.NotNil(t, a)
+
+	body := map[string]interface{}{}
+	client := loginServer(t, "auth/jwt/login", body)
+
+	secret, err := a.Login(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, "sometoken", secret.Auth.ClientToken)
+	assert.Equal(t, map[string]interface{}{"role": "ci", "jwt": "somejwt"}, body)
+
+	t.Setenv("VAULT_AUTH_JWT", "")
+	t.Setenv("VAULT_AUTH_JWT_FILE", "/run/secrets/jwt")
+	t.Setenv("VAULT_AUTH_JWT_MOUNT", "gitlab")
+	a = envJWTAuthAdapter(fsys)
+	require.NotNil(t, a)
+
+	body = map[string]interface{}{}
+	client = loginServer(t, "auth/gitlab/login", body)
+
+	_, err = a.Login(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"role": "ci", "jwt": "filejwt"}, body)
+}
+
+func TestEnvIAMAuthAdapter(t *testing.T) {
+	fsys := fstest.MapFS{}
+
+	t.Setenv("VAULT_AUTH_AWS_TYPE", "")
+	t.Setenv("VAULT_AUTH_AWS_ROLE", "myrole")
+	assert.Nil(t, envIAMAuthAdapter(fsys))
+	assert.NotNil(t, envEC2AuthAdapter(fsys))
+
+	t.Setenv("VAULT_AUTH_AWS_TYPE", "iam")
+	assert.NotNil(t, envIAMAuthAdapter(fsys))
+	assert.Nil(t, envEC2AuthAdapter(fsys))
+
+	t.Setenv("VAULT_AUTH_AWS_ROLE", "")
+	assert.Nil(t, envIAMAuthAdapter(fsys))
+}
@@ -422,6 +422,25 @@ func (w *wdFS) Remove(name string) error {
 	return hackpadfs.Remove(fsys, resolved)
 }
 
+func (w *wdFS) Rename(oldname, newname string) error {
+	oldRoot, oldResolved, err := resolveLocalPath(w.vol, oldname)
+	if err != nil {
+		return fmt.Errorf("resolve: %w", err)
+	}
+	newRoot, newResolved, err := resolveLocalPath(w.vol, newname)
+	if err != nil {
+		return fmt.Errorf("resolve: %w", err)
+	}
+	if oldRoot != newRoot {
+		return fmt.Errorf("rename %q to %q: can not rename across volumes", oldname, newname)
+	}
+	fsys, err := w.fsysFor(oldRoot)
+	if err != nil {
+		return err
+	}
+	return hackpadfs.Rename(fsys, oldResolved, newResolved)
+}
+
 func (w *wdFS) Chmod(name string, mode fs.FileMode) error {
 	root, resolved, err := resolveLocalPath(w.vol, name)
 	if err != nil {
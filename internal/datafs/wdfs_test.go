@@ -133,6 +133,20 @@ func TestWDFS_WriteOps(t *testing.T) {
 	assert.True(t, fi.Mode().IsRegular())
 	assert.Equal(t, "0444", fmt.Sprintf("%#o", fi.Mode().Perm()))
 
+	// rename it and back again
+	err = fsys.Rename("/tmp/foo", "/tmp/renamed")
+	require.NoError(t, err)
+
+	_, err = fsys.Stat("/tmp/foo")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	b, err = fs.ReadFile(fsys, "/tmp/renamed")
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(b))
+
+	err = fsys.Rename("/tmp/renamed", "/tmp/foo")
+	require.NoError(t, err)
+
 	// now delete it
 	err = fsys.Remove("/tmp/foo")
 	require.NoError(t, err)
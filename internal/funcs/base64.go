@@ -28,6 +28,12 @@ func (Base64Funcs) Encode(in interface{}) (string, error) {
 	return base64.Encode(b)
 }
 
+// EncodeURL -
+func (Base64Funcs) EncodeURL(in interface{}) (string, error) {
+	b := toBytes(in)
+	return base64.EncodeURL(b)
+}
+
 // Decode -
 func (Base64Funcs) Decode(in interface{}) (string, error) {
 	out, err := base64.Decode(conv.ToString(in))
@@ -34,6 +34,14 @@ func TestBase64Encode(t *testing.T) {
 	assert.Equal(t, "Zm9vYmFy", must(bf.Encode("foobar")))
 }
 
+func TestBase64EncodeURL(t *testing.T) {
+	t.Parallel()
+
+	bf := &Base64Funcs{}
+	assert.Equal(t, "eyJhbGciOiJIUzI1NiJ9", must(bf.EncodeURL(`{"alg":"HS256"}`)))
+	assert.Equal(t, "-_8", must(bf.EncodeURL([]byte{0xfb, 0xff})))
+}
+
 func TestBase64Decode(t *testing.T) {
 	t.Parallel()
 
@@ -57,6 +57,15 @@ func (ConvFuncs) ParseInt(s interface{}, base, bitSize int) (int64, error) {
 	return strconv.ParseInt(conv.ToString(s), base, bitSize)
 }
 
+// ParseBool -
+func (ConvFuncs) ParseBool(in interface{}) (bool, error) {
+	if b, ok := in.(bool); ok {
+		return b, nil
+	}
+
+	return conv.ParseBool(conv.ToString(in))
+}
+
 // ParseFloat -
 func (ConvFuncs) ParseFloat(s interface{}, bitSize int) (float64, error) {
 	return strconv.ParseFloat(conv.ToString(s), bitSize)
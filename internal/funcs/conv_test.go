@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateConvFuncs(t *testing.T) {
@@ -60,3 +61,24 @@ func TestDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestParseBool(t *testing.T) {
+	t.Parallel()
+
+	c := &ConvFuncs{}
+
+	out, err := c.ParseBool(true)
+	require.NoError(t, err)
+	assert.True(t, out)
+
+	out, err = c.ParseBool("on")
+	require.NoError(t, err)
+	assert.True(t, out)
+
+	out, err = c.ParseBool(0)
+	require.NoError(t, err)
+	assert.False(t, out)
+
+	_, err = c.ParseBool("ture")
+	require.Error(t, err)
+}
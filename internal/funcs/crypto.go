@@ -4,6 +4,7 @@ import (
 	"context"
 	gcrypto "crypto"
 	"crypto/elliptic"
+	"crypto/md5"  //nolint: gosec
 	"crypto/sha1" //nolint: gosec
 	"crypto/sha256"
 	"crypto/sha512"
@@ -69,6 +70,12 @@ func (f CryptoFuncs) WPAPSK(ssid, password interface{}) (string, error) {
 	return f.PBKDF2(password, ssid, 4096, 32)
 }
 
+// MD5 - Note: MD5 is cryptographically broken and should not be used for secure applications.
+func (f CryptoFuncs) MD5(input interface{}) string {
+	out, _ := f.MD5Bytes(input)
+	return fmt.Sprintf("%02x", out)
+}
+
 // SHA1 - Note: SHA-1 is cryptographically broken and should not be used for secure applications.
 func (f CryptoFuncs) SHA1(input interface{}) string {
 	out, _ := f.SHA1Bytes(input)
@@ -115,6 +122,15 @@ func (f CryptoFuncs) SHA512_256(input interface{}) string {
 	return fmt.Sprintf("%02x", out)
 }
 
+// MD5Bytes - Note: MD5 is cryptographically broken and should not be used for secure applications.
+func (CryptoFuncs) MD5Bytes(input interface{}) ([]byte, error) {
+	//nolint:gosec
+	b := md5.Sum(toBytes(input))
+	out := make([]byte, len(b))
+	copy(out, b[:])
+	return out, nil
+}
+
 // SHA1 - Note: SHA-1 is cryptographically broken and should not be used for secure applications.
 func (CryptoFuncs) SHA1Bytes(input interface{}) ([]byte, error) {
 	//nolint:gosec
@@ -3,6 +3,7 @@ package funcs
 import (
 	"context"
 	"encoding/base64"
+	"encoding/hex"
 	"strconv"
 	"strings"
 	"testing"
@@ -77,6 +78,18 @@ func TestSHA(t *testing.T) {
 	assert.Equal(t, sha512_256, c.SHA512_256(in))
 }
 
+func TestMD5(t *testing.T) {
+	t.Parallel()
+
+	c := testCryptoNS()
+	assert.Equal(t, "900150983cd24fb0d6963f7d28e17f72", c.MD5("abc"))
+	assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", c.MD5(""))
+
+	b, err := c.MD5Bytes([]byte("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, "900150983cd24fb0d6963f7d28e17f72", hex.EncodeToString(b))
+}
+
 func TestBcrypt(t *testing.T) {
 	t.Parallel()
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
@@ -26,6 +27,7 @@ func CreateDataSourceFuncs(ctx context.Context, sr datafs.DataSourceReader) map[
 	f["datasource"] = ns.Datasource
 	f["ds"] = ns.Datasource
 	f["datasourceExists"] = ns.DatasourceExists
+	f["datasourceMeta"] = ns.DatasourceMeta
 	f["datasourceReachable"] = ns.DatasourceReachable
 	f["defineDatasource"] = ns.DefineDatasource
 	f["include"] = ns.Include
@@ -97,6 +99,27 @@ func (d *dataSourceFuncs) DatasourceReachable(alias string, args ...string) bool
 	return err == nil
 }
 
+// DatasourceMeta - Reads from the named datasource, and returns metadata about
+// the read: the content type, and for HTTP datasources the response status and
+// headers. Multiple values for the same header are joined with ", ".
+func (d *dataSourceFuncs) DatasourceMeta(alias string, args ...string) (map[string]interface{}, error) {
+	meta, err := d.sr.ReadSourceMeta(d.ctx, alias, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]string, len(meta.Header))
+	for k, v := range meta.Header {
+		headers[k] = strings.Join(v, ", ")
+	}
+
+	return map[string]interface{}{
+		"contentType": meta.ContentType,
+		"status":      meta.Status,
+		"headers":     headers,
+	}, nil
+}
+
 // Show all datasources  -
 func (d *dataSourceFuncs) ListDatasources() []string {
 	return d.sr.List()
@@ -2,12 +2,16 @@ package funcs
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"runtime"
 	"strconv"
 	"testing"
 	"testing/fstest"
 
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/go-fsimpl/httpfs"
 	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
@@ -113,6 +117,62 @@ func TestDatasourceReachable(t *testing.T) {
 	assert.False(t, data.DatasourceReachable("bar"))
 }
 
+func TestDatasourceMeta(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/config.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", iohelpers.JSONMimetype)
+		w.Header().Set("ETag", `"v42"`)
+		w.Header().Add("X-Version", "1")
+		w.Header().Add("X-Version", "2")
+		_, _ = w.Write([]byte(`{"foo": "bar"}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	fsys := datafs.WrapWdFS(fstest.MapFS{
+		"tmp/foo.txt": &fstest.MapFile{Data: []byte("hello")},
+	})
+
+	fsp := fsimpl.NewMux()
+	fsp.Add(httpfs.FS)
+	fsp.Add(datafs.WrappedFSProvider(fsys, "file", ""))
+	ctx := datafs.ContextWithFSProvider(context.Background(), fsp)
+
+	mustParseURL := func(s string) *url.URL {
+		u, err := url.Parse(s)
+		require.NoError(t, err)
+		return u
+	}
+
+	reg := datafs.NewRegistry()
+	reg.Register("api", config.DataSource{URL: mustParseURL(srv.URL + "/config.json")})
+	reg.Register("file", config.DataSource{URL: mustParseURL("file:///tmp/foo.txt")})
+	reg.Register("missing", config.DataSource{URL: mustParseURL(srv.URL + "/missing")})
+
+	data := &dataSourceFuncs{sr: datafs.NewSourceReader(reg), ctx: ctx}
+
+	meta, err := data.DatasourceMeta("api")
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.JSONMimetype, meta["contentType"])
+	assert.Equal(t, http.StatusOK, meta["status"])
+
+	headers := meta["headers"].(map[string]string)
+	assert.Equal(t, `"v42"`, headers["Etag"])
+	assert.Equal(t, "1, 2", headers["X-Version"])
+
+	meta, err = data.DatasourceMeta("file")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"contentType": "text/plain; charset=utf-8",
+		"status":      0,
+		"headers":     map[string]string{},
+	}, meta)
+
+	_, err = data.DatasourceMeta("missing")
+	require.Error(t, err)
+}
+
 func TestDatasourceExists(t *testing.T) {
 	reg := datafs.NewRegistry()
 	reg.Register("foo", config.DataSource{})
@@ -2,6 +2,7 @@ package funcs
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
 	"github.com/hairyhenderson/gomplate/v4/env"
@@ -31,3 +32,15 @@ func (EnvFuncs) Getenv(key interface{}, def ...string) string {
 func (EnvFuncs) ExpandEnv(s interface{}) string {
 	return env.ExpandEnv(conv.ToString(s))
 }
+
+// GetAll -
+func (EnvFuncs) GetAll(prefix ...string) (map[string]string, error) {
+	switch len(prefix) {
+	case 0:
+		return env.GetAll(""), nil
+	case 1:
+		return env.GetAll(prefix[0]), nil
+	default:
+		return nil, fmt.Errorf("wrong number of args: want 0 or 1, got %d", len(prefix))
+	}
+}
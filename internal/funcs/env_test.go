@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateEnvFuncs(t *testing.T) {
@@ -35,3 +36,19 @@ func TestEnvGetenv(t *testing.T) {
 
 	assert.Equal(t, "foo", ef.Getenv("bogusenvvar", "foo"))
 }
+
+func TestEnvGetAll(t *testing.T) {
+	t.Setenv("ENVGETALLTEST_PORT", "8080")
+
+	ef := &EnvFuncs{}
+	all, err := ef.GetAll()
+	require.NoError(t, err)
+	assert.Equal(t, "8080", all["ENVGETALLTEST_PORT"])
+
+	all, err = ef.GetAll("ENVGETALLTEST_")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"port": "8080"}, all)
+
+	_, err = ef.GetAll("a", "b")
+	require.Error(t, err)
+}
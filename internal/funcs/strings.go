@@ -37,6 +37,7 @@ func CreateStringFuncs(ctx context.Context) map[string]interface{} {
 	f["toLower"] = ns.ToLower
 	f["trimSpace"] = ns.TrimSpace
 	f["indent"] = ns.Indent
+	f["nindent"] = ns.NIndent
 	f["quote"] = ns.Quote
 	f["shellQuote"] = ns.ShellQuote
 	f["squote"] = ns.Squote
@@ -308,11 +309,40 @@ func (StringFuncs) Indent(args ...interface{}) (string, error) {
 	return gompstrings.Indent(width, indent, input)
 }
 
+// NIndent - like Indent, but prepends a newline
+func (f StringFuncs) NIndent(args ...interface{}) (string, error) {
+	out, err := f.Indent(args...)
+	if err != nil {
+		return "", err
+	}
+
+	return "\n" + out, nil
+}
+
 // Slug -
 func (StringFuncs) Slug(in interface{}) string {
 	return slug.Make(conv.ToString(in))
 }
 
+// Slugify -
+func (StringFuncs) Slugify(args ...interface{}) (string, error) {
+	// DNS labels can be at most 63 characters long
+	maxLength := 63
+	switch len(args) {
+	case 1:
+	case 2:
+		n, err := conv.ToInt(args[0])
+		if err != nil {
+			return "", fmt.Errorf("expected maxLength to be a number: %w", err)
+		}
+		maxLength = n
+	default:
+		return "", fmt.Errorf("expected 1 or 2 args, got %d", len(args))
+	}
+
+	return gompstrings.Slugify(maxLength, conv.ToString(args[len(args)-1])), nil
+}
+
 // Quote -
 func (StringFuncs) Quote(in interface{}) string {
 	return fmt.Sprintf("%q", conv.ToString(in))
@@ -3,6 +3,7 @@ package funcs
 import (
 	"context"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -59,6 +60,30 @@ func TestIndent(t *testing.T) {
 	}
 }
 
+func TestNIndent(t *testing.T) {
+	t.Parallel()
+
+	sf := &StringFuncs{}
+
+	testdata := []struct {
+		out  string
+		args []interface{}
+	}{
+		{"\n foo\n bar\n baz", []interface{}{"foo\nbar\nbaz"}},
+		{"\n    foo\n    bar\n", []interface{}{4, "foo\nbar\n"}},
+		{"\n---foo", []interface{}{3, "-", "foo"}},
+	}
+
+	for _, d := range testdata {
+		out, err := sf.NIndent(d.args...)
+		require.NoError(t, err)
+		assert.Equal(t, d.out, out)
+	}
+
+	_, err := sf.NIndent()
+	require.Error(t, err)
+}
+
 func TestTrimPrefix(t *testing.T) {
 	t.Parallel()
 
@@ -150,6 +175,44 @@ func TestSlug(t *testing.T) {
 	assert.Equal(t, "100", s)
 }
 
+func TestSlugify(t *testing.T) {
+	sf := &StringFuncs{}
+
+	s, err := sf.Slugify("My Service: Frontend (EU)")
+	require.NoError(t, err)
+	assert.Equal(t, "my-service-frontend-eu", s)
+
+	s, err = sf.Slugify(10, "My Service: Frontend (EU)")
+	require.NoError(t, err)
+	assert.Equal(t, "my-service", s)
+
+	s, err = sf.Slugify("10", 42)
+	require.NoError(t, err)
+	assert.Equal(t, "42", s)
+
+	// output is capped at 63 characters by default, but the length can be
+	// given explicitly, or set to 0 for no limit
+	long := strings.Repeat("abcdefghij ", 10)
+	s, err = sf.Slugify(long)
+	require.NoError(t, err)
+	assert.Len(t, s, 63)
+	assert.Equal(t, "abcdefghij-abcdefghij-abcdefghij-abcdefghij-abcdefghij-abcdefgh", s)
+
+	s, err = sf.Slugify(100, long)
+	require.NoError(t, err)
+	assert.Len(t, s, 100)
+
+	s, err = sf.Slugify(0, long)
+	require.NoError(t, err)
+	assert.Len(t, s, 109)
+
+	_, err = sf.Slugify("ten", "foo")
+	require.Error(t, err)
+
+	_, err = sf.Slugify()
+	require.Error(t, err)
+}
+
 func TestSort(t *testing.T) {
 	t.Parallel()
 	sf := &StringFuncs{ctx: context.Background()}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/hack-pad/hackpadfs"
 	osfs "github.com/hack-pad/hackpadfs/os"
@@ -69,3 +70,86 @@ func TestWrite(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "Hello subdirranean world!", string(out))
 }
+
+func TestReplaceWriteCloser(t *testing.T) {
+	dir := t.TempDir()
+	fsys := datafs.WrapWdFS(osfs.NewFS())
+
+	foopath := filepath.Join(dir, "foo")
+	err := os.WriteFile(foopath, []byte("original"), 0o640)
+	require.NoError(t, err)
+
+	w := iohelpers.ReplaceWriteCloser(fsys, foopath, iohelpers.NormalizeFileMode(0o640), false)
+	_, err = w.Write([]byte("Hello "))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("world"))
+	require.NoError(t, err)
+
+	// nothing is written until Close
+	out, err := os.ReadFile(foopath)
+	require.NoError(t, err)
+	assert.Equal(t, "original", string(out))
+
+	require.NoError(t, w.Close())
+
+	out, err = os.ReadFile(foopath)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello world", string(out))
+
+	fi, err := os.Stat(foopath)
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.NormalizeFileMode(0o640), fi.Mode())
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// discarded output doesn't replace the file
+	w = iohelpers.ReplaceWriteCloser(fsys, foopath, iohelpers.NormalizeFileMode(0o640), false)
+	_, err = w.Write([]byte("partial"))
+	require.NoError(t, err)
+	w.Discard()
+	require.NoError(t, w.Close())
+
+	out, err = os.ReadFile(foopath)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello world", string(out))
+
+	// unchanged content doesn't replace the file, so the modification time is
+	// preserved
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(foopath, past, past))
+
+	w = iohelpers.ReplaceWriteCloser(fsys, foopath, iohelpers.NormalizeFileMode(0o600), false)
+	_, err = w.Write([]byte("Hello world"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	fi, err = os.Stat(foopath)
+	require.NoError(t, err)
+	assert.Equal(t, past, fi.ModTime())
+
+	// but the mode is still updated
+	assert.Equal(t, iohelpers.NormalizeFileMode(0o600), fi.Mode())
+
+	// empty or whitespace-only output never replaces the file, even when forced
+	w = iohelpers.ReplaceWriteCloser(fsys, foopath, iohelpers.NormalizeFileMode(0o600), true)
+	_, err = w.Write([]byte(" \n\t\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	out, err = os.ReadFile(foopath)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello world", string(out))
+
+	// forcing replaces the file even when the content is unchanged
+	w = iohelpers.ReplaceWriteCloser(fsys, foopath, iohelpers.NormalizeFileMode(0o600), true)
+	_, err = w.Write([]byte("Hello world"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	fi, err = os.Stat(foopath)
+	require.NoError(t, err)
+	assert.NotEqual(t, past, fi.ModTime())
+}
@@ -129,12 +129,14 @@ func SameSkipper(r io.Reader, open func() (io.WriteCloser, error)) io.WriteClose
 // then flushes and writes to the wrapped writer.
 func (f *sameSkipper) Write(p []byte) (n int, err error) {
 	if !f.diff {
+		// a single Read may return fewer bytes than are available, which
+		// would look like a difference, so read fully
 		in := make([]byte, len(p))
-		_, err := f.r.Read(in)
-		if err != nil && err != io.EOF {
+		n, err := io.ReadFull(f.r, in)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
 			return 0, fmt.Errorf("failed to read: %w", err)
 		}
-		if bytes.Equal(in, p) {
+		if bytes.Equal(in[:n], p) {
 			return f.buf.Write(p)
 		}
 
@@ -271,3 +273,143 @@ func assertPathInWD(filename string) error {
 	}
 	return nil
 }
+
+// ReplaceWriteCloser returns an io.WriteCloser which buffers everything
+// written to it, and replaces filename with the buffered content on Close. The
+// content is first written to a temporary file in the same directory, which is
+// then renamed over filename, so filename is never left partially written.
+//
+// The temporary file is created with the given mode, to preserve the original
+// file's permissions. If Discard is called before Close, filename is left
+// untouched. As with NewEmptySkipper, filename is also left untouched when
+// the content is empty or only whitespace, and unless force is set, when the
+// content is unchanged.
+func ReplaceWriteCloser(fsys fs.FS, filename string, mode os.FileMode, force bool) *ReplacingWriteCloser {
+	return &ReplacingWriteCloser{
+		fsys:     fsys,
+		filename: filename,
+		mode:     mode,
+		force:    force,
+		buf:      &bytes.Buffer{},
+	}
+}
+
+// ReplacingWriteCloser replaces a file with the content written to it - see
+// ReplaceWriteCloser.
+type ReplacingWriteCloser struct {
+	fsys      fs.FS
+	buf       *bytes.Buffer
+	filename  string
+	mode      os.FileMode
+	discarded bool
+	closed    bool
+	force     bool
+}
+
+var _ io.WriteCloser = (*ReplacingWriteCloser)(nil)
+
+func (r *ReplacingWriteCloser) Write(p []byte) (int, error) {
+	if r.closed {
+		return 0, fs.ErrClosed
+	}
+	return r.buf.Write(p)
+}
+
+// Discard abandons the buffered content, so that Close will not replace the
+// file.
+func (r *ReplacingWriteCloser) Discard() {
+	r.discarded = true
+}
+
+func (r *ReplacingWriteCloser) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	// empty output is never written, the same as for other output files
+	if r.discarded || allWhitespace(r.buf.Bytes()) {
+		return nil
+	}
+
+	// leave the file alone if the content hasn't changed, so that its
+	// modification time is preserved
+	if !r.force {
+		if same, err := r.unchanged(); err != nil || same {
+			return err
+		}
+	}
+
+	tmpName, err := r.writeTemp()
+	if err != nil {
+		return err
+	}
+
+	err = hackpadfs.Rename(r.fsys, tmpName, r.filename)
+	if err != nil {
+		_ = hackpadfs.Remove(r.fsys, tmpName)
+		return fmt.Errorf("failed to replace %s: %w", r.filename, err)
+	}
+
+	return nil
+}
+
+// unchanged returns true when the target file already has the buffered
+// content. The file's mode is still updated if it differs.
+func (r *ReplacingWriteCloser) unchanged() (bool, error) {
+	current, err := fs.ReadFile(r.fsys, r.filename)
+	if err != nil || !bytes.Equal(current, r.buf.Bytes()) {
+		return false, nil
+	}
+
+	fi, err := fs.Stat(r.fsys, r.filename)
+	if err != nil {
+		return false, nil
+	}
+
+	if fi.Mode().Perm() != r.mode.Perm() {
+		err = hackpadfs.Chmod(r.fsys, r.filename, r.mode)
+		if err != nil {
+			return false, fmt.Errorf("failed to chmod %s: %w", r.filename, err)
+		}
+	}
+
+	return true, nil
+}
+
+// writeTemp writes the buffered content to a new temporary file next to the
+// target file, returning its name
+func (r *ReplacingWriteCloser) writeTemp() (string, error) {
+	dir, base := filepath.Split(r.filename)
+
+	var f fs.File
+	var tmpName string
+	var err error
+	for i := 0; ; i++ {
+		tmpName = filepath.Join(dir, fmt.Sprintf(".%s.%d.%d.tmp", base, os.Getpid(), i))
+		f, err = hackpadfs.OpenFile(r.fsys, tmpName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, r.mode)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, fs.ErrExist) || i >= 100 {
+			return "", fmt.Errorf("failed to create temporary file for %s: %w", r.filename, err)
+		}
+	}
+
+	_, err = hackpadfs.WriteFile(f, r.buf.Bytes())
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	// the mode given to OpenFile is subject to the umask, so set it explicitly
+	if err == nil {
+		err = hackpadfs.Chmod(r.fsys, tmpName, r.mode)
+	}
+
+	if err != nil {
+		_ = hackpadfs.Remove(r.fsys, tmpName)
+		return "", fmt.Errorf("failed to write temporary file for %s: %w", r.filename, err)
+	}
+
+	return tmpName, nil
+}
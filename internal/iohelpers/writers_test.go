@@ -122,6 +122,24 @@ func TestSameSkipper(t *testing.T) {
 	}
 }
 
+func TestSameSkipperLargeWrites(t *testing.T) {
+	// writes bigger than the read buffer must still be detected as the same
+	content := bytes.Repeat([]byte("0123456789abcdef"), 1024)
+
+	opened := false
+	f := SameSkipper(bytes.NewReader(content), func() (io.WriteCloser, error) {
+		opened = true
+		return newBufferCloser(&bytes.Buffer{}), nil
+	})
+
+	for _, chunk := range [][]byte{content[:10], content[10:9000], content[9000:]} {
+		_, err := f.Write(chunk)
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+	assert.False(t, opened)
+}
+
 func TestLazyWriteCloser(t *testing.T) {
 	w := newBufferCloser(&bytes.Buffer{})
 	opened := false
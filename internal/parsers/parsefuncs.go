@@ -9,6 +9,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"unicode/utf8"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
@@ -410,13 +411,46 @@ func toJSONBytes(in interface{}) ([]byte, error) {
 	h := &codec.JsonHandle{}
 	h.Canonical = true
 	buf := new(bytes.Buffer)
-	err := codec.NewEncoder(buf, h).Encode(in)
+	err := codec.NewEncoder(buf, h).Encode(jsonNormalize(in))
 	if err != nil {
 		return nil, fmt.Errorf("unable to marshal %s: %w", in, err)
 	}
 	return buf.Bytes(), nil
 }
 
+// jsonNormalize - convert []byte values which are valid UTF-8 to strings,
+// anywhere in the given maps and slices, so that they are encoded as text
+// rather than as base64. Other values are left alone.
+func jsonNormalize(in interface{}) interface{} {
+	switch v := in.(type) {
+	case []byte:
+		if utf8.Valid(v) {
+			return string(v)
+		}
+		return v
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			out[k] = jsonNormalize(e)
+		}
+		return out
+	case map[interface{}]interface{}:
+		out := make(map[interface{}]interface{}, len(v))
+		for k, e := range v {
+			out[k] = jsonNormalize(e)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			out[i] = jsonNormalize(e)
+		}
+		return out
+	default:
+		return in
+	}
+}
+
 // ToJSON - Stringify a struct as JSON
 func ToJSON(in interface{}) (string, error) {
 	s, err := toJSONBytes(in)
@@ -199,6 +199,19 @@ func TestToJSON(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestToJSONNormalization(t *testing.T) {
+	in := map[string]interface{}{
+		"text":   []byte("hello"),
+		"binary": []byte{0xff, 0xfe},
+		"list":   []interface{}{[]byte("a"), 1},
+		"nested": map[interface{}]interface{}{"b": []byte("world")},
+		"time":   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	out, err := ToJSON(in)
+	require.NoError(t, err)
+	assert.Equal(t, `{"binary":"//4=","list":["a",1],"nested":{"b":"world"},"text":"hello","time":"2024-01-02T03:04:05Z"}`, out)
+}
+
 func TestToJSONPretty(t *testing.T) {
 	expected := `{
   "down": {
@@ -44,3 +44,28 @@ func ParseSourceURL(value string) (*url.URL, error) {
 
 	return srcURL, nil
 }
+
+// Redact returns the URL as a string suitable for logging. Like
+// [url.URL.Redacted] the password is masked, and query parameter values are
+// masked too, since they often carry tokens and other secrets.
+func Redact(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	if u.RawQuery == "" {
+		return u.Redacted()
+	}
+
+	r := *u
+	q := r.Query()
+	for k, vs := range q {
+		for i, v := range vs {
+			if v != "" {
+				q[k][i] = "xxxxx"
+			}
+		}
+	}
+	r.RawQuery = q.Encode()
+
+	return r.Redacted()
+}
@@ -2,8 +2,10 @@
 package random
 
 import (
+	crand "crypto/rand"
 	"fmt"
 	"math"
+	"math/big"
 	"math/rand"
 	"regexp"
 	"unicode"
@@ -38,12 +40,22 @@ func StringBounds(count int, lower, upper rune) (r string, err error) {
 	return rndString(count, chars)
 }
 
-// produce a string containing a random selection of given characters
+// produce a string containing a random selection of given characters. Since
+// these strings are often used as generated secrets, the characters are chosen
+// with crypto/rand rather than math/rand.
 func rndString(count int, chars []rune) (string, error) {
+	if len(chars) == 0 {
+		return "", fmt.Errorf("no characters to choose from")
+	}
+
+	n := big.NewInt(int64(len(chars)))
 	s := make([]rune, count)
 	for i := range s {
-		//nolint:gosec
-		s[i] = chars[rand.Intn(len(chars))]
+		idx, err := crand.Int(crand.Reader, n)
+		if err != nil {
+			return "", fmt.Errorf("generate random string: %w", err)
+		}
+		s[i] = chars[idx.Int64()]
 	}
 	return string(s), nil
 }
@@ -46,6 +46,9 @@ func TestStringRE(t *testing.T) {
 
 	_, err = StringRE(1, "[bogus")
 	require.Error(t, err)
+
+	_, err = StringRE(1, "[^\\x00-\\x{10FFFF}]")
+	require.Error(t, err)
 }
 
 func TestStringBounds(t *testing.T) {
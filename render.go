@@ -15,6 +15,7 @@ import (
 
 	"github.com/hairyhenderson/go-fsimpl"
 	"github.com/hairyhenderson/go-fsimpl/autofs"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 	"github.com/hairyhenderson/gomplate/v4/internal/funcs"
 )
@@ -28,6 +29,10 @@ type RenderOptions struct {
 	// Context - map of datasources to be read immediately and added to the
 	// template's context
 	Context map[string]DataSource
+	// Values - list of datasources to be read immediately and deep-merged, in
+	// order, into the root of the template's context. Later values override
+	// earlier ones, and entries from Context override both.
+	Values []DataSource
 	// Templates - map of templates that can be referenced as nested templates
 	Templates map[string]DataSource
 
@@ -56,6 +61,7 @@ func optionsFromConfig(cfg *Config) RenderOptions {
 	opts := RenderOptions{
 		Datasources:  cfg.DataSources,
 		Context:      cfg.Context,
+		Values:       cfg.Values,
 		Templates:    cfg.Templates,
 		ExtraHeaders: cfg.ExtraHeaders,
 		LDelim:       cfg.LDelim,
@@ -67,13 +73,14 @@ func optionsFromConfig(cfg *Config) RenderOptions {
 }
 
 type renderer struct {
-	sr          datafs.DataSourceReader
-	nested      map[string]DataSource
-	funcs       template.FuncMap
-	lDelim      string
-	rDelim      string
-	missingKey  string
-	tctxAliases []string
+	sr           datafs.DataSourceReader
+	nested       map[string]DataSource
+	funcs        template.FuncMap
+	lDelim       string
+	rDelim       string
+	missingKey   string
+	valueAliases []string
+	tctxAliases  []string
 }
 
 // Renderer provides gomplate's core template rendering functionality.
@@ -108,20 +115,23 @@ func newRenderer(opts RenderOptions) *renderer {
 	// this should be the only place where this registry is created
 	reg := datafs.NewRegistry()
 
+	// values are registered with reserved aliases, so they can't collide
+	// with user-defined datasources
+	valueAliases := make([]string, 0, len(opts.Values))
+	for i, ds := range opts.Values {
+		alias := datafs.ValuesAlias(i)
+		valueAliases = append(valueAliases, alias)
+		reg.Register(alias, ds)
+	}
+
 	tctxAliases := []string{}
 
 	for alias, ds := range opts.Context {
 		tctxAliases = append(tctxAliases, alias)
-		reg.Register(alias, DataSource{
-			URL:    ds.URL,
-			Header: ds.Header,
-		})
+		reg.Register(alias, ds)
 	}
 	for alias, ds := range opts.Datasources {
-		reg.Register(alias, DataSource{
-			URL:    ds.URL,
-			Header: ds.Header,
-		})
+		reg.Register(alias, ds)
 	}
 
 	// convert the internal Templates to a map[string]Datasource
@@ -150,13 +160,14 @@ func newRenderer(opts RenderOptions) *renderer {
 	sr := datafs.NewSourceReader(reg)
 
 	return &renderer{
-		nested:      opts.Templates,
-		sr:          sr,
-		funcs:       opts.Funcs,
-		tctxAliases: tctxAliases,
-		lDelim:      opts.LDelim,
-		rDelim:      opts.RDelim,
-		missingKey:  missingKey,
+		nested:       opts.Templates,
+		sr:           sr,
+		funcs:        opts.Funcs,
+		valueAliases: valueAliases,
+		tctxAliases:  tctxAliases,
+		lDelim:       opts.LDelim,
+		rDelim:       opts.RDelim,
+		missingKey:   missingKey,
 	}
 }
 
@@ -179,7 +190,7 @@ func (r *renderer) RenderTemplates(ctx context.Context, templates []Template) er
 
 	// configure the template context with the refreshed Data value
 	// only done here because the data context may have changed
-	tmplctx, err := createTmplContext(ctx, r.tctxAliases, r.sr)
+	tmplctx, err := createTmplContext(ctx, r.valueAliases, r.tctxAliases, r.sr)
 	if err != nil {
 		return err
 	}
@@ -210,15 +221,60 @@ func (r *renderer) renderTemplatesWithData(ctx context.Context, templates []Temp
 	return nil
 }
 
-func (r *renderer) renderTemplate(ctx context.Context, template Template, f template.FuncMap, tmplctx interface{}) error {
+// replacingWriter is implemented by writers which replace their target file
+// only when closed, and which can abandon their output instead
+type replacingWriter interface {
+	io.Closer
+	Discard()
+}
+
+func (r *renderer) renderTemplate(ctx context.Context, template Template, f template.FuncMap, tmplctx interface{}) (err error) {
 	if template.Writer != nil {
 		if wr, ok := template.Writer.(io.Closer); ok {
 			defer wr.Close()
 		}
+
+		// writers that replace files (i.e. --in-place) must not be left with
+		// partially-rendered output, and failures to replace the file must be
+		// reported
+		if rw, ok := template.Writer.(replacingWriter); ok {
+			defer func() {
+				if err != nil {
+					rw.Discard()
+					return
+				}
+				if cerr := rw.Close(); cerr != nil {
+					err = fmt.Errorf("failed to write %s: %w", template.Name, cerr)
+				}
+			}()
+		}
+	}
+
+	text, lDelim, rDelim := template.Text, r.lDelim, r.rDelim
+	if config.ExperimentalEnabled(ctx) {
+		fm, body, ferr := parseFrontMatter(text)
+		if ferr != nil {
+			return fmt.Errorf("parse template %s: %w", template.Name, ferr)
+		}
+
+		if fm != nil {
+			text = body
+			if fm.LDelim != "" {
+				lDelim = fm.LDelim
+			}
+			if fm.RDelim != "" {
+				rDelim = fm.RDelim
+			}
+
+			tmplctx, err = fm.applyValues(tmplctx)
+			if err != nil {
+				return fmt.Errorf("parse template %s: %w", template.Name, err)
+			}
+		}
 	}
 
 	tstart := time.Now()
-	tmpl, err := r.parseTemplate(ctx, template.Name, template.Text, f, tmplctx)
+	tmpl, err := r.parseTemplate(ctx, template.Name, text, lDelim, rDelim, f, tmplctx)
 	if err != nil {
 		return fmt.Errorf("parse template %s: %w", template.Name, err)
 	}
@@ -240,8 +296,9 @@ func (r *renderer) Render(ctx context.Context, name, text string, wr io.Writer)
 	})
 }
 
-// parseTemplate - parses text as a Go template with the given name and options
-func (r *renderer) parseTemplate(ctx context.Context, name, text string, funcs template.FuncMap, tmplctx interface{}) (tmpl *template.Template, err error) {
+// parseTemplate - parses text as a Go template with the given name, delimiters,
+// and options
+func (r *renderer) parseTemplate(ctx context.Context, name, text, lDelim, rDelim string, funcs template.FuncMap, tmplctx interface{}) (tmpl *template.Template, err error) {
 	tmpl = template.New(name)
 
 	missingKey := r.missingKey
@@ -261,7 +318,7 @@ func (r *renderer) parseTemplate(ctx context.Context, name, text string, funcs t
 	// the "tmpl" funcs get added here because they need access to the root template and context
 	addTmplFuncs(funcMap, tmpl, tmplctx, name)
 	tmpl.Funcs(funcMap)
-	tmpl.Delims(r.lDelim, r.rDelim)
+	tmpl.Delims(lDelim, rDelim)
 	_, err = tmpl.Parse(text)
 	if err != nil {
 		return nil, err
@@ -78,6 +78,33 @@ func TestRenderTemplate(t *testing.T) {
 	tr = NewRenderer(RenderOptions{})
 	err = tr.Render(ctx, "foo", `{{ bogus }}`, &bytes.Buffer{})
 	assert.ErrorContains(t, err, "template: foo:")
+
+	// replacing writers are discarded when rendering fails
+	rw := &replacingBuffer{}
+	err = tr.Render(ctx, "foo", `hello {{ fail "boom" }}`, rw)
+	require.Error(t, err)
+	assert.True(t, rw.discarded)
+	assert.True(t, rw.closed)
+
+	rw = &replacingBuffer{}
+	err = tr.Render(ctx, "foo", `hello`, rw)
+	require.NoError(t, err)
+	assert.False(t, rw.discarded)
+	assert.True(t, rw.closed)
+	assert.Equal(t, "hello", rw.String())
+}
+
+type replacingBuffer struct {
+	bytes.Buffer
+	discarded bool
+	closed    bool
+}
+
+func (b *replacingBuffer) Discard() { b.discarded = true }
+
+func (b *replacingBuffer) Close() error {
+	b.closed = true
+	return nil
 }
 
 //// examples
@@ -161,3 +188,35 @@ func ExampleRenderer_datasources() {
 	// Output:
 	// 🌎 one.one.one.one is served by AS13335 Cloudflare, Inc.
 }
+
+func TestValuesAliasesDontCollide(t *testing.T) {
+	fsmux := fsimpl.NewMux()
+	fsmux.Add(datafs.EnvFS)
+	ctx := datafs.ContextWithFSProvider(context.Background(), fsmux)
+
+	t.Setenv("vals", "foo: values\n")
+	t.Setenv("other", "foo: datasource\n")
+
+	valsURL := "env:///vals?type=application/yaml"
+	tr := newRenderer(RenderOptions{
+		Values: []DataSource{{URL: mustURL(valsURL)}},
+		// a datasource with the values source's URL as its alias
+		Datasources: map[string]DataSource{
+			valsURL: {URL: mustURL("env:///other?type=application/yaml")},
+		},
+	})
+
+	require.Len(t, tr.valueAliases, 1)
+	assert.NotEqual(t, valsURL, tr.valueAliases[0])
+
+	_, b, err := tr.sr.ReadSource(ctx, tr.valueAliases[0])
+	require.NoError(t, err)
+	assert.Equal(t, "foo: values\n", string(b))
+
+	_, b, err = tr.sr.ReadSource(ctx, valsURL)
+	require.NoError(t, err)
+	assert.Equal(t, "foo: datasource\n", string(b))
+
+	// reserved aliases aren't listed
+	assert.Equal(t, []string{valsURL}, tr.sr.List())
+}
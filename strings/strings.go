@@ -60,6 +60,21 @@ func Trunc(length int, s string) string {
 	return s[0:length]
 }
 
+var nonDNSLabel = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify - convert a string to a form that's safe to use as an RFC 1123 DNS
+// label: lowercase ASCII letters and digits, with each run of any other
+// characters replaced by a single '-', and no leading or trailing '-'. When
+// maxLength is greater than 0 the result is truncated to at most that length.
+func Slugify(maxLength int, s string) string {
+	s = nonDNSLabel.ReplaceAllString(strings.ToLower(s), "-")
+	s = strings.Trim(s, "-")
+	if maxLength > 0 && len(s) > maxLength {
+		s = strings.TrimRight(s[:maxLength], "-")
+	}
+	return s
+}
+
 // Sort - return an alphanumerically-sorted list of strings
 //
 // Deprecated: use coll.Sort instead
@@ -75,6 +75,29 @@ func TestShellQuote(t *testing.T) {
 	assert.Equal(t, `'it'"'"'s its'`, ShellQuote(`it's its`))
 }
 
+func TestSlugify(t *testing.T) {
+	testdata := []struct {
+		in       string
+		expected string
+		max      int
+	}{
+		{"", "", 0},
+		{"hello", "hello", 0},
+		{"Hello, World!", "hello-world", 0},
+		{"  --My Service (v2)--  ", "my-service-v2", 0},
+		{"snake_case.and.dots", "snake-case-and-dots", 0},
+		{"Café del Mar", "caf-del-mar", 0},
+		{"!!!", "", 0},
+		{"hello world", "hello", 6},
+		{"hello world", "hello-w", 7},
+		{"hello world", "hello-world", -1},
+	}
+
+	for _, d := range testdata {
+		assert.Equal(t, d.expected, Slugify(d.max, d.in), "input: %q, max: %d", d.in, d.max)
+	}
+}
+
 func TestSort(t *testing.T) {
 	in := []string{}
 	expected := []string{}
@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"text/template"
 
 	"github.com/hack-pad/hackpadfs"
+	"github.com/hairyhenderson/go-fsimpl"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
 	"github.com/hairyhenderson/gomplate/v4/tmpl"
@@ -56,7 +59,7 @@ func gatherTemplates(ctx context.Context, cfg *Config, outFileNamer outputNamer)
 	case cfg.Input != "":
 		// open the output file - no need to close it, as it will be closed by the
 		// caller later
-		target, oerr := openOutFile(ctx, cfg.OutputFiles[0], 0o755, mode, modeOverride, cfg.Stdout)
+		target, oerr := openOutFile(ctx, cfg.OutputFiles[0], 0o755, mode, modeOverride, cfg.Force, cfg.Stdout)
 		if oerr != nil {
 			return nil, fmt.Errorf("openOutFile: %w", oerr)
 		}
@@ -73,6 +76,14 @@ func gatherTemplates(ctx context.Context, cfg *Config, outFileNamer outputNamer)
 		if err != nil {
 			return nil, fmt.Errorf("walkDir: %w", err)
 		}
+	case cfg.InPlace:
+		templates = make([]Template, len(cfg.InputFiles))
+		for i, f := range cfg.InputFiles {
+			templates[i], err = inPlaceTemplate(ctx, cfg, f, mode)
+			if err != nil {
+				return nil, fmt.Errorf("inPlaceTemplate: %w", err)
+			}
+		}
 	case len(cfg.InputFiles) > 0:
 		templates = make([]Template, len(cfg.InputFiles))
 		for i, f := range cfg.InputFiles {
@@ -142,6 +153,9 @@ func walkDir(ctx context.Context, cfg *Config, dir string, outFileNamer outputNa
 
 	passthroughFiles := make(map[string]bool)
 
+	// output file names, mapped to the input files they're rendered from
+	outFiles := make(map[string]string)
+
 	for _, file := range excludeProcessingMatches.MatchedFiles {
 		// files that need to be directly copied
 		passthroughFiles[file] = true
@@ -159,6 +173,20 @@ func walkDir(ctx context.Context, cfg *Config, dir string, outFileNamer outputNa
 			return nil, fmt.Errorf("outFileNamer: %w", err)
 		}
 
+		if prev, ok := outFiles[outFile]; ok {
+			return nil, fmt.Errorf("input files %q and %q would both be written to %q", prev, inPath, outFile)
+		}
+		outFiles[outFile] = inPath
+
+		// Ensure file parent dirs - use separate fsys for output file
+		outfsys, err := datafs.FSysForPath(ctx, outFile)
+		if err != nil {
+			return nil, fmt.Errorf("fsysForPath: %w", err)
+		}
+		if err = mkdirAllFromSource(outfsys, outFile, subfsys, file, dirMode); err != nil {
+			return nil, err
+		}
+
 		_, ok := passthroughFiles[file]
 		if ok {
 			err = copyFileToOutDir(ctx, cfg, inPath, outFile, mode, modeOverride)
@@ -174,22 +202,13 @@ func walkDir(ctx context.Context, cfg *Config, dir string, outFileNamer outputNa
 			return nil, fmt.Errorf("fileToTemplate: %w", err)
 		}
 
-		// Ensure file parent dirs - use separate fsys for output file
-		outfsys, err := datafs.FSysForPath(ctx, outFile)
-		if err != nil {
-			return nil, fmt.Errorf("fsysForPath: %w", err)
-		}
-		if err = hackpadfs.MkdirAll(outfsys, filepath.Dir(outFile), dirMode); err != nil {
-			return nil, fmt.Errorf("mkdirAll %q: %w", outFile, err)
-		}
-
 		templates = append(templates, tpl)
 	}
 
 	return templates, nil
 }
 
-func readInFile(ctx context.Context, inFile string, mode os.FileMode) (source string, newmode os.FileMode, err error) {
+func readInFile(ctx context.Context, cfg *Config, inFile string, mode os.FileMode) (source string, newmode os.FileMode, err error) {
 	newmode = mode
 	var b []byte
 
@@ -210,17 +229,53 @@ func readInFile(ctx context.Context, inFile string, mode os.FileMode) (source st
 			return source, newmode, fmt.Errorf("fsysForPath: %w", err)
 		}
 
-		si, err = fs.Stat(fsys, inFile)
+		name := inFile
+		remote := isRemoteTemplate(inFile)
+		if remote {
+			// remote templates are read relative to the root of the URL
+			u, _ := url.Parse(inFile)
+			_, name = datafs.SplitFSMuxURL(u)
+			fsys = fsimpl.WithContextFS(ctx, fsys)
+
+			// apply the same headers and TLS client certificate options as
+			// for HTTP datasources
+			src := cfg.InputFileSources[inFile]
+			fsys = fsimpl.WithHeaderFS(src.Header, fsys)
+
+			var client *http.Client
+			client, err = datafs.ClientCertHTTPClient(ctx, src)
+			if err != nil {
+				return source, newmode, fmt.Errorf("template %q: %w", inFile, err)
+			}
+			if client != nil {
+				fsys = fsimpl.WithHTTPClientFS(client, fsys)
+			}
+		}
+
+		// open the file once, so that remote templates are only fetched once
+		var f fs.File
+		f, err = fsys.Open(name)
+		if err != nil {
+			return source, newmode, fmt.Errorf("open %q: %w", inFile, err)
+		}
+		defer f.Close()
+
+		si, err = f.Stat()
 		if err != nil {
 			return source, newmode, fmt.Errorf("stat %q: %w", inFile, err)
 		}
 		if mode == 0 {
 			newmode = si.Mode()
+
+			// the remote file's mode isn't meaningful for output files
+			if remote {
+				newmode = iohelpers.NormalizeFileMode(0o644)
+			}
 		}
 
 		// we read the file and store in memory immediately, to prevent leaking
 		// file descriptors.
-		b, err = fs.ReadFile(fsys, inFile)
+		b, err = io.ReadAll(f)
 		if err != nil {
 			return source, newmode, fmt.Errorf("readAll %q: %w", inFile, err)
 		}
@@ -230,10 +285,21 @@ func readInFile(ctx context.Context, inFile string, mode os.FileMode) (source st
 	return source, newmode, err
 }
 
+// isRemoteTemplate returns true if inFile is an HTTP(S) URL rather than a
+// local path
+func isRemoteTemplate(inFile string) bool {
+	u, err := url.Parse(inFile)
+	if err != nil {
+		return false
+	}
+
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
 func getOutfileHandler(ctx context.Context, cfg *Config, outFile string, mode os.FileMode, modeOverride bool) (io.Writer, error) {
 	// open the output file - no need to close it, as it will be closed by the
 	// caller later
-	target, err := openOutFile(ctx, outFile, 0o755, mode, modeOverride, cfg.Stdout)
+	target, err := openOutFile(ctx, outFile, 0o755, mode, modeOverride, cfg.Force, cfg.Stdout)
 	if err != nil {
 		return nil, fmt.Errorf("openOutFile: %w", err)
 	}
@@ -241,8 +307,50 @@ func getOutfileHandler(ctx context.Context, cfg *Config, outFile string, mode os
 	return target, nil
 }
 
+// mkdirAllFromSource creates the parent directories of outFile. Each directory
+// that corresponds to a directory in the input (i.e. where the output path ends
+// with the same directory names as the input file's path, relative to the input
+// dir) is created with the mode of the input directory. Any other parent
+// directories are created with dirMode. Existing directories are not modified.
+func mkdirAllFromSource(outfsys fs.FS, outFile string, srcfsys fs.FS, srcFile string, dirMode os.FileMode) error {
+	type outDir struct {
+		name string
+		mode os.FileMode
+	}
+
+	dirs := []outDir{}
+	outParent := filepath.Dir(outFile)
+	for srcDir := filepath.Dir(srcFile); srcDir != "."; srcDir = filepath.Dir(srcDir) {
+		if filepath.Base(outParent) != filepath.Base(srcDir) {
+			break
+		}
+
+		fi, err := fs.Stat(srcfsys, filepath.ToSlash(srcDir))
+		if err != nil {
+			return fmt.Errorf("stat %q: %w", srcDir, err)
+		}
+
+		dirs = append(dirs, outDir{name: outParent, mode: fi.Mode().Perm()})
+		outParent = filepath.Dir(outParent)
+	}
+
+	if err := hackpadfs.MkdirAll(outfsys, outParent, dirMode); err != nil {
+		return fmt.Errorf("mkdirAll %q: %w", outParent, err)
+	}
+
+	// create from the top down, so each directory gets its own mode
+	for i := len(dirs) - 1; i >= 0; i-- {
+		err := hackpadfs.Mkdir(outfsys, dirs[i].name, dirs[i].mode)
+		if err != nil && !errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("mkdir %q: %w", dirs[i].name, err)
+		}
+	}
+
+	return nil
+}
+
 func copyFileToOutDir(ctx context.Context, cfg *Config, inFile, outFile string, mode os.FileMode, modeOverride bool) error {
-	sourceStr, newmode, err := readInFile(ctx, inFile, mode)
+	sourceStr, newmode, err := readInFile(ctx, cfg, inFile, mode)
 	if err != nil {
 		return err
 	}
@@ -262,7 +370,7 @@ func copyFileToOutDir(ctx context.Context, cfg *Config, inFile, outFile string,
 }
 
 func fileToTemplate(ctx context.Context, cfg *Config, inFile, outFile string, mode os.FileMode, modeOverride bool) (Template, error) {
-	source, newmode, err := readInFile(ctx, inFile, mode)
+	source, newmode, err := readInFile(ctx, cfg, inFile, mode)
 	if err != nil {
 		return Template{}, err
 	}
@@ -281,25 +389,49 @@ func fileToTemplate(ctx context.Context, cfg *Config, inFile, outFile string, mo
 	return tmpl, nil
 }
 
+// inPlaceTemplate - read inFile as a template which, once rendered, replaces
+// the original file. The replacement is atomic, and the original file's mode
+// is kept unless mode is set.
+func inPlaceTemplate(ctx context.Context, cfg *Config, inFile string, mode os.FileMode) (Template, error) {
+	source, newmode, err := readInFile(ctx, cfg, inFile, mode)
+	if err != nil {
+		return Template{}, err
+	}
+
+	fsys, err := datafs.FSysForPath(ctx, inFile)
+	if err != nil {
+		return Template{}, fmt.Errorf("fsysForPath: %w", err)
+	}
+
+	tmpl := Template{
+		Name:   inFile,
+		Text:   source,
+		Writer: iohelpers.ReplaceWriteCloser(fsys, inFile, iohelpers.NormalizeFileMode(newmode.Perm()), cfg.Force),
+	}
+
+	return tmpl, nil
+}
+
 // openOutFile returns a writer for the given file, creating the file if it
 // doesn't exist yet, and creating the parent directories if necessary. Will
 // defer actual opening until the first non-empty write. If the file already
-// exists, it will not be overwritten until the first difference is encountered.
+// exists, it will not be overwritten until the first difference is encountered,
+// unless force is set.
 //
 // TODO: dirMode is always called with 0o755 - should either remove or make it configurable
 //
 //nolint:unparam
-func openOutFile(ctx context.Context, filename string, dirMode, mode os.FileMode, modeOverride bool, stdout io.Writer) (out io.Writer, err error) {
+func openOutFile(ctx context.Context, filename string, dirMode, mode os.FileMode, modeOverride, force bool, stdout io.Writer) (out io.Writer, err error) {
 	out = iohelpers.NewEmptySkipper(func() (io.Writer, error) {
 		if filename == "-" {
 			return iohelpers.NopCloser(stdout), nil
 		}
-		return createOutFile(ctx, filename, dirMode, mode, modeOverride)
+		return createOutFile(ctx, filename, dirMode, mode, modeOverride, force)
 	})
 	return out, nil
 }
 
-func createOutFile(ctx context.Context, filename string, dirMode, mode os.FileMode, modeOverride bool) (out io.WriteCloser, err error) {
+func createOutFile(ctx context.Context, filename string, dirMode, mode os.FileMode, modeOverride, force bool) (out io.WriteCloser, err error) {
 	// we only support writing out to local files for now
 	fsys, err := datafs.FSysForPath(ctx, filename)
 	if err != nil {
@@ -329,9 +461,10 @@ func createOutFile(ctx context.Context, filename string, dirMode, mode os.FileMo
 		return out, err
 	}
 
-	// if the output file already exists, we'll use a SameSkipper
+	// if the output file already exists, we'll use a SameSkipper (unless
+	// forced to always write)
 	fi, err := hackpadfs.Stat(fsys, filename)
-	if err != nil {
+	if err != nil || force {
 		// likely means the file just doesn't exist - further errors will be more useful
 		return iohelpers.LazyWriteCloser(open), nil
 	}
@@ -5,14 +5,19 @@ import (
 	"context"
 	"io"
 	"io/fs"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"testing"
 	"testing/fstest"
 	"text/template"
+	"time"
 
 	"github.com/hack-pad/hackpadfs"
 	"github.com/hack-pad/hackpadfs/mem"
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/go-fsimpl/httpfs"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
 
@@ -28,7 +33,7 @@ func TestOpenOutFile(t *testing.T) {
 
 	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file"))
 
-	f, err := openOutFile(ctx, "/tmp/foo", 0o755, 0o644, false, nil)
+	f, err := openOutFile(ctx, "/tmp/foo", 0o755, 0o644, false, false, nil)
 	require.NoError(t, err)
 
 	_, err = f.Write([]byte("hello world"))
@@ -45,7 +50,7 @@ func TestOpenOutFile(t *testing.T) {
 
 	out := &bytes.Buffer{}
 
-	f, err = openOutFile(ctx, "-", 0o755, 0o644, false, out)
+	f, err = openOutFile(ctx, "-", 0o755, 0o644, false, false, out)
 	require.NoError(t, err)
 
 	_, err = f.Write([]byte("hello world"))
@@ -165,6 +170,101 @@ func TestGatherTemplates(t *testing.T) {
 	require.Len(t, templates, 3)
 	assert.Equal(t, "foo", templates[0].Text)
 	hackpadfs.Remove(fsys, "out")
+
+	templates, err = gatherTemplates(ctx, &Config{
+		InputFiles: []string{"foo"},
+		InPlace:    true,
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "bar", templates[0].Text)
+
+	_, err = templates[0].Writer.Write([]byte("hello world"))
+	require.NoError(t, err)
+
+	// the input file is only replaced once the writer is closed
+	b, err := fs.ReadFile(fsys, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "bar", string(b))
+
+	wc, ok := templates[0].Writer.(io.Closer)
+	require.True(t, ok)
+	require.NoError(t, wc.Close())
+
+	b, err = fs.ReadFile(fsys, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(b))
+
+	info, err = hackpadfs.Stat(fsys, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.NormalizeFileMode(0o600), info.Mode())
+}
+
+func TestReadInFileRemote(t *testing.T) {
+	gets := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tmpl/hello.tmpl", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets++
+		}
+		w.Write([]byte(`hello {{ "world" }}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	fsp := fsimpl.NewMux()
+	fsp.Add(httpfs.FS)
+	ctx := datafs.ContextWithFSProvider(context.Background(), fsp)
+
+	source, mode, err := readInFile(ctx, &Config{}, srv.URL+"/tmpl/hello.tmpl", 0)
+	require.NoError(t, err)
+	assert.Equal(t, `hello {{ "world" }}`, source)
+	assert.Equal(t, iohelpers.NormalizeFileMode(0o644), mode)
+	assert.Equal(t, 1, gets)
+
+	_, mode, err = readInFile(ctx, &Config{}, srv.URL+"/tmpl/hello.tmpl", 0o755)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), mode)
+
+	_, _, err = readInFile(ctx, &Config{}, srv.URL+"/tmpl/missing.tmpl", 0)
+	require.ErrorContains(t, err, "404")
+}
+
+func TestReadInFileRemoteHeaders(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tmpl/secret.tmpl", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer foo" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`secret`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	fsp := fsimpl.NewMux()
+	fsp.Add(httpfs.FS)
+	ctx := datafs.ContextWithFSProvider(context.Background(), fsp)
+
+	inFile := srv.URL + "/tmpl/secret.tmpl"
+
+	_, _, err := readInFile(ctx, &Config{}, inFile, 0)
+	require.ErrorContains(t, err, "401")
+
+	cfg := &Config{
+		InputFileSources: map[string]DataSource{
+			inFile: {Header: http.Header{"Authorization": {"Bearer foo"}}},
+		},
+	}
+	source, _, err := readInFile(ctx, cfg, inFile, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", source)
+
+	cfg.InputFileSources[inFile] = DataSource{ClientKey: "client.key"}
+	_, _, err = readInFile(ctx, cfg, inFile, 0)
+	require.Error(t, err)
 }
 
 func TestCreateOutFile(t *testing.T) {
@@ -173,11 +273,41 @@ func TestCreateOutFile(t *testing.T) {
 
 	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file"))
 
-	_, err := createOutFile(ctx, "in", 0o755, 0o644, false)
+	_, err := createOutFile(ctx, "in", 0o755, 0o644, false, false)
 	require.Error(t, err)
 	assert.IsType(t, &fs.PathError{}, err)
 }
 
+func TestCreateOutFileForce(t *testing.T) {
+	fsys, _ := mem.NewFS()
+	require.NoError(t, hackpadfs.WriteFullFile(fsys, "out", []byte("hello"), 0o644))
+
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, hackpadfs.Chtimes(fsys, "out", past, past))
+
+	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file"))
+
+	write := func(force bool) time.Time {
+		t.Helper()
+
+		w, err := createOutFile(ctx, "out", 0o755, 0o644, false, force)
+		require.NoError(t, err)
+		_, err = w.Write([]byte("hello"))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		fi, err := hackpadfs.Stat(fsys, "out")
+		require.NoError(t, err)
+		return fi.ModTime()
+	}
+
+	// identical content isn't rewritten...
+	assert.Equal(t, past, write(false))
+
+	// ...unless forced
+	assert.NotEqual(t, past, write(true))
+}
+
 func TestParseNestedTemplates(t *testing.T) {
 	wd, _ := os.Getwd()
 	t.Cleanup(func() {
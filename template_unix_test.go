@@ -4,6 +4,7 @@ package gomplate
 
 import (
 	"context"
+	"io/fs"
 	"testing"
 
 	"github.com/hack-pad/hackpadfs"
@@ -55,3 +56,57 @@ func TestWalkDir_UNIX(t *testing.T) {
 		assert.Equal(t, expected[i].Text, tmpl.Text)
 	}
 }
+
+func TestWalkDir_DirModes(t *testing.T) {
+	memfs, _ := mem.NewFS()
+	fsys := datafs.WrapWdFS(memfs)
+
+	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file"))
+
+	require.NoError(t, hackpadfs.MkdirAll(fsys, "/indir", 0o755))
+	require.NoError(t, hackpadfs.Mkdir(fsys, "/indir/bin", 0o700))
+	require.NoError(t, hackpadfs.Mkdir(fsys, "/indir/bin/deep", 0o750))
+	require.NoError(t, hackpadfs.WriteFullFile(fsys, "/indir/bin/deep/run.sh", []byte("#!/bin/sh"), 0o755))
+	require.NoError(t, hackpadfs.Mkdir(fsys, "/indir/raw", 0o711))
+	require.NoError(t, hackpadfs.WriteFullFile(fsys, "/indir/raw/data.bin", []byte("data"), 0o644))
+
+	cfg := &Config{}
+	_, err := walkDir(ctx, cfg, "/indir", simpleNamer("/outdir"), nil, []string{"raw/*"}, 0, false)
+	require.NoError(t, err)
+
+	for name, mode := range map[string]fs.FileMode{
+		"/outdir":          0o755,
+		"/outdir/bin":      0o700,
+		"/outdir/bin/deep": 0o750,
+		"/outdir/raw":      0o711,
+	} {
+		fi, err := hackpadfs.Stat(fsys, name)
+		require.NoError(t, err)
+		assert.True(t, fi.IsDir())
+		assert.Equal(t, mode, fi.Mode().Perm(), name)
+	}
+
+	// the passthrough file is copied immediately, with the source mode
+	fi, err := hackpadfs.Stat(fsys, "/outdir/raw/data.bin")
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0o644), fi.Mode().Perm())
+}
+
+func TestWalkDir_OutputCollision(t *testing.T) {
+	memfs, _ := mem.NewFS()
+	fsys := datafs.WrapWdFS(memfs)
+
+	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file"))
+
+	require.NoError(t, hackpadfs.MkdirAll(fsys, "/indir", 0o755))
+	require.NoError(t, hackpadfs.WriteFullFile(fsys, "/indir/a.tmpl", []byte("a"), 0o644))
+	require.NoError(t, hackpadfs.WriteFullFile(fsys, "/indir/b.tmpl", []byte("b"), 0o644))
+
+	namer := outputNamerFunc(func(_ context.Context, _ string) (string, error) {
+		return "/outdir/out.txt", nil
+	})
+
+	cfg := &Config{}
+	_, err := walkDir(ctx, cfg, "/indir", namer, nil, nil, 0, false)
+	require.ErrorContains(t, err, `"/indir/a.tmpl" and "/indir/b.tmpl" would both be written to "/outdir/out.txt"`)
+}
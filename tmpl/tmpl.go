@@ -3,21 +3,30 @@ package tmpl
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"text/template"
 )
 
+// maxDepth is the maximum number of nested Inline/Exec calls - this guards
+// against infinite recursion, for example when an inline template read from a
+// datasource renders itself
+const maxDepth = 1000
+
 // Template -
 type Template struct {
 	root       *template.Template
 	defaultCtx interface{}
 	path       string
+
+	// depth of nested Inline/Exec calls
+	depth int
 }
 
 // New -
 func New(root *template.Template, tctx interface{}, path string) *Template {
-	return &Template{root, tctx, path}
+	return &Template{root: root, defaultCtx: tctx, path: path}
 }
 
 // Path - returns the path to the current template if it came from a file.
@@ -56,7 +65,7 @@ func (t *Template) inline(name, in string, ctx interface{}) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return render(tmpl, ctx)
+	return t.render(tmpl, ctx)
 }
 
 // Exec - execute (render) a template - this is the built-in `template` action, except with output...
@@ -69,18 +78,38 @@ func (t *Template) Exec(name string, tmplcontext ...interface{}) (string, error)
 	if tmpl == nil {
 		return "", fmt.Errorf(`template "%s" not defined`, name)
 	}
-	return render(tmpl, ctx)
+	return t.render(tmpl, ctx)
 }
 
-func render(tmpl *template.Template, ctx interface{}) (string, error) {
+func (t *Template) render(tmpl *template.Template, ctx interface{}) (string, error) {
+	if t.depth >= maxDepth {
+		return "", &depthError{name: tmpl.Name()}
+	}
+	t.depth++
+	defer func() { t.depth-- }()
+
 	out := &bytes.Buffer{}
 	err := tmpl.Execute(out, ctx)
 	if err != nil {
+		// return the original error, rather than one wrapped at every level
+		var derr *depthError
+		if errors.As(err, &derr) {
+			return "", derr
+		}
 		return "", err
 	}
 	return out.String(), nil
 }
 
+// depthError is returned when templates are nested deeper than maxDepth
+type depthError struct {
+	name string
+}
+
+func (e *depthError) Error() string {
+	return fmt.Sprintf("template %q: exceeded maximum nesting depth (%d) - possible infinite recursion", e.name, maxDepth)
+}
+
 func (t *Template) parseArgs(args ...interface{}) (name, in string, ctx interface{}, err error) {
 	name = "<inline>"
 	ctx = t.defaultCtx
@@ -28,6 +28,26 @@ func TestInline(t *testing.T) {
 	}
 }
 
+func TestInlineRecursion(t *testing.T) {
+	// the snippet renders itself, as it might if read from a datasource
+	ctx := map[string]string{"snippet": "{{ tpl .snippet . }}"}
+	tmpl := &Template{
+		defaultCtx: ctx,
+		root:       template.New("root"),
+	}
+	tmpl.root.Funcs(template.FuncMap{
+		"tpl": tmpl.Inline,
+	})
+
+	_, err := tmpl.Inline(ctx["snippet"])
+	require.EqualError(t, err, `template "<inline>": exceeded maximum nesting depth (1000) - possible infinite recursion`)
+
+	// the depth is reset afterwards
+	out, err := tmpl.Inline("{{ tpl `hello` }}")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", out)
+}
+
 func TestParseArgs(t *testing.T) {
 	defaultCtx := map[string]string{"hello": "world"}
 	tmpl := New(nil, defaultCtx, "")